@@ -1,3 +1,3 @@
-module rb-tree-map
+module github.com/A1exMedvedev/RB_tree_MAP
 
 go 1.25.0
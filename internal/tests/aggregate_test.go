@@ -0,0 +1,215 @@
+package tests
+
+import (
+	"fmt"
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var sumMonoid = rbtree.Monoid[int, int]{
+	Identity: 0,
+	Combine:  func(a, b int) int { return a + b },
+}
+
+func TestAggregateMapBasic(t *testing.T) {
+	tree := rbtree.NewWithMonoid[int](sumMonoid)
+	for _, k := range []int{10, 20, 30, 40, 50} {
+		tree.Insert(k, k/10)
+	}
+
+	t.Run("Sum", func(t *testing.T) {
+		if sum := tree.AggregateAll(); sum != 15 {
+			t.Errorf("Expected a total of 15, but got %d", sum)
+		}
+		if sum := tree.Aggregate(20, 40); sum != 5 {
+			t.Errorf("Expected the sum over [20, 40) to be 5, but got %d", sum)
+		}
+		if sum := tree.Aggregate(20, 40, rbtree.ExcludeLo, rbtree.IncludeHi); sum != 7 {
+			t.Errorf("Expected the sum over (20, 40] to be 7, but got %d", sum)
+		}
+		if sum := tree.Aggregate(41, 49); sum != 0 {
+			t.Errorf("Expected the sum over an empty range to be 0, but got %d", sum)
+		}
+	})
+
+	t.Run("Updates", func(t *testing.T) {
+		tree.Insert(30, 100)
+		if sum := tree.Aggregate(0, 100); sum != 112 {
+			t.Errorf("Expected a sum of 112 after replacing a value, but got %d", sum)
+		}
+		tree.Remove(10)
+		if sum := tree.AggregateAll(); sum != 111 {
+			t.Errorf("Expected a sum of 111 after removing a key, but got %d", sum)
+		}
+	})
+
+	t.Run("Min And Predicate Count", func(t *testing.T) {
+		minimum := rbtree.NewWithMonoid[string](rbtree.Monoid[float64, float64]{
+			Identity: math.Inf(1),
+			Combine:  math.Min,
+		})
+		minimum.Insert("a", 3.5)
+		minimum.Insert("b", -1)
+		minimum.Insert("c", 2)
+		if v := minimum.Aggregate("b", "d"); v != -1 {
+			t.Errorf("Expected the minimum over [b, d) to be -1, but got %v", v)
+		}
+		if v := minimum.Aggregate("c", "d"); v != 2 {
+			t.Errorf("Expected the minimum over [c, d) to be 2, but got %v", v)
+		}
+
+		evens := rbtree.NewWithMonoid[int](rbtree.Monoid[int, int]{
+			Lift: func(v int) int {
+				if v%2 == 0 {
+					return 1
+				}
+				return 0
+			},
+			Combine: func(a, b int) int { return a + b },
+		})
+		for i := 0; i < 10; i++ {
+			evens.Insert(i, i*i)
+		}
+		if count := evens.Aggregate(3, 8); count != 2 {
+			t.Errorf("Expected 2 even squares for keys in [3, 8), but got %d", count)
+		}
+	})
+
+	t.Run("Nil Lift", func(t *testing.T) {
+		expectPanic(t, "NewWithMonoid with a nil Lift and different types", func() {
+			rbtree.NewWithMonoid[int](rbtree.Monoid[int, string]{
+				Combine: func(a, b string) string { return a + b },
+			})
+		})
+
+		words := rbtree.NewWithMonoid[int](rbtree.Monoid[fmt.Stringer, fmt.Stringer]{
+			Combine: func(a, b fmt.Stringer) fmt.Stringer {
+				if a == nil {
+					return b
+				}
+				return a
+			},
+		})
+		words.Insert(1, nil)
+		words.Insert(2, time.Second)
+		if first := words.AggregateAll(); first != time.Second {
+			t.Errorf("Expected the first non-nil value to be 1s, but got %v", first)
+		}
+	})
+}
+
+func TestAggregateMapModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running aggregate model test with seed: %d", seed)
+
+	// Concatenation is not commutative, so it also checks that the
+	// aggregates are combined in key order.
+	tree := rbtree.NewWithMonoid[int](rbtree.Monoid[string, string]{
+		Combine: func(a, b string) string { return a + b },
+	})
+	model := make(map[int]string)
+	letters := "abcdefghijklmnopqrstuvwxyz"
+
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(300)
+		if rng.Intn(3) == 0 {
+			delete(model, key)
+			tree.Remove(key)
+		} else {
+			value := string(letters[rng.Intn(len(letters))])
+			model[key] = value
+			tree.Insert(key, value)
+		}
+
+		lo := rng.Intn(320) - 10
+		hi := lo + rng.Intn(100)
+		var expected strings.Builder
+		for k := lo; k < hi; k++ {
+			expected.WriteString(model[k])
+		}
+		if actual := tree.Aggregate(lo, hi); actual != expected.String() {
+			t.Fatalf("Step %d: expected aggregate %q over [%d, %d), but got %q", i, expected.String(), lo, hi, actual)
+		}
+	}
+
+	var all strings.Builder
+	for _, v := range tree.InOrder() {
+		all.WriteString(v)
+	}
+	if tree.AggregateAll() != all.String() {
+		t.Fatalf("Expected AggregateAll to be %q, but got %q", all.String(), tree.AggregateAll())
+	}
+}
+
+func TestAggregateMapNavigation(t *testing.T) {
+	tree := rbtree.NewWithMonoid[int](sumMonoid)
+	for _, k := range []int{10, 20, 30, 40, 50} {
+		tree.Insert(k, k/10)
+	}
+
+	if k, v, ok := tree.Floor(35); !ok || k != 30 || v != 3 {
+		t.Errorf("Expected Floor(35) to return 30=3, but got %d=%d, ok=%v", k, v, ok)
+	}
+	if k, v, ok := tree.Higher(30); !ok || k != 40 || v != 4 {
+		t.Errorf("Expected Higher(30) to return 40=4, but got %d=%d, ok=%v", k, v, ok)
+	}
+	if k, v, ok := tree.Select(1); !ok || k != 20 || v != 2 {
+		t.Errorf("Expected Select(1) to return 20=2, but got %d=%d, ok=%v", k, v, ok)
+	}
+	if r := tree.Rank(40); r != 3 {
+		t.Errorf("Expected Rank(40) to be 3, but got %d", r)
+	}
+	if n := tree.CountRange(20, 50); n != 3 {
+		t.Errorf("Expected 3 keys in [20, 50), but got %d", n)
+	}
+	if actual := collectKeys(tree.RangeFromBackward(30)); !slices.Equal([]int{50, 40, 30}, actual) {
+		t.Errorf("Unexpected RangeFromBackward(30) content: %v", actual)
+	}
+
+	if k, v, ok := tree.PopMax(); !ok || k != 50 || v != 5 {
+		t.Errorf("Expected PopMax to return 50=5, but got %d=%d, ok=%v", k, v, ok)
+	}
+	if sum := tree.AggregateAll(); sum != 10 {
+		t.Errorf("Expected a total of 10 after PopMax, but got %d", sum)
+	}
+}
+
+func TestAggregateMapCursorAndSnapshot(t *testing.T) {
+	tree := rbtree.NewWithMonoid[int](sumMonoid)
+	for i := 1; i <= 10; i++ {
+		tree.Insert(i, i)
+	}
+	snap := tree.Snapshot()
+
+	c := tree.Cursor()
+	for c.First(); c.Valid(); {
+		if c.Key()%3 == 0 {
+			c.Delete()
+		} else {
+			c.SetValue(c.Value() * 10)
+			c.Next()
+		}
+	}
+	if sum := tree.AggregateAll(); sum != 10*(55-18) {
+		t.Errorf("Expected a total of %d after the cursor updates, but got %d", 10*(55-18), sum)
+	}
+	if sum := tree.Aggregate(1, 5); sum != 10*(1+2+4) {
+		t.Errorf("Expected the sum over [1, 5) to be %d, but got %d", 10*(1+2+4), sum)
+	}
+
+	if snap.Size() != 10 || snap.AggregateAll() != 55 {
+		t.Errorf("Expected the snapshot to keep 10 entries summing to 55, but got %d summing to %d", snap.Size(), snap.AggregateAll())
+	}
+	if sum := snap.Aggregate(3, 7); sum != 3+4+5+6 {
+		t.Errorf("Expected the snapshot sum over [3, 7) to be 18, but got %d", sum)
+	}
+	if v, ok := snap.Get(3); !ok || v != 3 {
+		t.Errorf("Expected the snapshot to keep 3=3, but got %d, ok=%v", v, ok)
+	}
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBackwardTraversal(t *testing.T) {
+	tree := rbtree.New[int, string]()
+	keysToInsert := []int{10, 85, 15, 70, 20, 60, 30, 50, 65, 80, 90, 40, 5, 55}
+	for _, key := range keysToInsert {
+		tree.Insert(key, "v"+string(rune(key)))
+	}
+
+	expectedOrder := []int{90, 85, 80, 70, 65, 60, 55, 50, 40, 30, 20, 15, 10, 5}
+	actualOrder := make([]int, 0, tree.Size())
+	for k, v := range tree.Backward() {
+		if v != "v"+string(rune(k)) {
+			t.Errorf("Unexpected value %q for key %d", v, k)
+		}
+		actualOrder = append(actualOrder, k)
+	}
+	if !slices.Equal(expectedOrder, actualOrder) {
+		t.Errorf("Backward traversal is incorrect.\nExpected: %v\nGot:      %v", expectedOrder, actualOrder)
+	}
+
+	emptyTree := rbtree.New[int, string]()
+	for k := range emptyTree.Backward() {
+		t.Errorf("Backward on an empty tree should yield nothing, but got %d", k)
+	}
+}
+
+func TestBackwardEarlyStop(t *testing.T) {
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+
+	actual := make([]int, 0)
+	for k := range tree.Backward() {
+		if k == 96 {
+			break
+		}
+		actual = append(actual, k)
+	}
+	if !slices.Equal([]int{99, 98, 97}, actual) {
+		t.Errorf("Expected iteration to stop at 96, got %v", actual)
+	}
+}
+
+func TestRangeBackwardBounds(t *testing.T) {
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 100; i += 10 {
+		tree.Insert(i, i)
+	}
+
+	cases := []struct {
+		name     string
+		seq      iter.Seq2[int, int]
+		expected []int
+	}{
+		{"Default Half-Open", tree.RangeBackward(20, 50), []int{40, 30, 20}},
+		{"Include Hi", tree.RangeBackward(20, 50, rbtree.IncludeHi), []int{50, 40, 30, 20}},
+		{"Exclude Lo", tree.RangeBackward(20, 50, rbtree.ExcludeLo), []int{40, 30}},
+		{"Bounds Between Keys", tree.RangeBackward(15, 45), []int{40, 30, 20}},
+		{"Empty When Lo Above Hi", tree.RangeBackward(60, 20), []int{}},
+		{"RangeFromBackward", tree.RangeFromBackward(75), []int{90, 80}},
+		{"RangeToBackward", tree.RangeToBackward(20), []int{10, 0}},
+		{"RangeToBackward Inclusive", tree.RangeToBackward(20, rbtree.IncludeHi), []int{20, 10, 0}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := collectKeys(tc.seq)
+			if !slices.Equal(tc.expected, actual) {
+				t.Errorf("Expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRangeBackwardModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running backward range model test with seed: %d", seed)
+
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 3000; i++ {
+		key := rng.Intn(5000)
+		tree.Insert(key, key)
+	}
+
+	for i := 0; i < 200; i++ {
+		lo, hi := rng.Intn(5200)-100, rng.Intn(5200)-100
+		expected := collectKeys(tree.Range(lo, hi))
+		slices.Reverse(expected)
+		actual := collectKeys(tree.RangeBackward(lo, hi))
+		if !slices.Equal(expected, actual) {
+			t.Fatalf("RangeBackward(%d, %d) mismatch.\nExpected: %v\nGot:      %v", lo, hi, expected, actual)
+		}
+	}
+}
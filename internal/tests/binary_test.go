@@ -0,0 +1,271 @@
+package tests
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"io"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*rbtree.RBTreeMap[int, int])(nil)
+	_ encoding.BinaryUnmarshaler = (*rbtree.RBTreeMap[int, int])(nil)
+	_ io.WriterTo                = (*rbtree.RBTreeMap[int, int])(nil)
+	_ io.ReaderFrom              = (*rbtree.RBTreeMap[int, int])(nil)
+)
+
+type fixedPoint struct {
+	X, Y int32
+}
+
+// upperCodec stores strings in upper case, to check that SetCodecs is used.
+type upperCodec struct{}
+
+func (upperCodec) Encode(w io.Writer, v string) error {
+	if err := binary.Write(w, binary.LittleEndian, uint16(len(v))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, strings.ToUpper(v))
+	return err
+}
+
+func (upperCodec) Decode(r io.Reader) (string, error) {
+	var n uint16
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return "", err
+	}
+	data := make([]byte, n)
+	_, err := io.ReadFull(r, data)
+	return string(data), err
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	t.Run("String Keys", func(t *testing.T) {
+		tree := createTestTree()
+		data, err := tree.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		loaded := rbtree.New[int, string]()
+		loaded.Insert(999, "stale")
+		if err := loaded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if loaded.ContainsKey(999) {
+			t.Error("Expected UnmarshalBinary to replace the existing entries")
+		}
+		if !maps.Equal(maps.Collect(tree.InOrder()), maps.Collect(loaded.InOrder())) {
+			t.Errorf("Expected %v, but got %v", maps.Collect(tree.InOrder()), maps.Collect(loaded.InOrder()))
+		}
+	})
+
+	t.Run("Fixed Size Values And Time Keys", func(t *testing.T) {
+		compareTime := func(a, b time.Time) int { return a.Compare(b) }
+		tree := rbtree.NewWithComparator[time.Time, fixedPoint](compareTime)
+		base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		for i := 0; i < 10; i++ {
+			tree.Insert(base.Add(time.Duration(i)*time.Minute), fixedPoint{int32(i), int32(-i)})
+		}
+		data, err := tree.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		loaded := rbtree.NewWithComparator[time.Time, fixedPoint](compareTime)
+		if err := loaded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if loaded.Size() != tree.Size() {
+			t.Fatalf("Expected size %d, but got %d", tree.Size(), loaded.Size())
+		}
+		if v, ok := loaded.Get(base.Add(7 * time.Minute)); !ok || v != (fixedPoint{7, -7}) {
+			t.Errorf("Expected {7 -7}, but got %v, %v", v, ok)
+		}
+	})
+
+	t.Run("Custom Codec", func(t *testing.T) {
+		tree := rbtree.New[int, string]()
+		tree.Insert(1, "one")
+		tree.SetCodecs(nil, upperCodec{})
+		data, err := tree.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		loaded := rbtree.New[int, string]()
+		loaded.SetCodecs(nil, upperCodec{})
+		if err := loaded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if v, _ := loaded.Get(1); v != "ONE" {
+			t.Errorf("Expected the custom codec to be used, but got %q", v)
+		}
+	})
+
+	t.Run("Unsupported Type", func(t *testing.T) {
+		tree := rbtree.New[int, map[int]int]()
+		tree.Insert(1, map[int]int{})
+		if _, err := tree.MarshalBinary(); err == nil {
+			t.Error("Expected an error for a value type without a codec, but got nil")
+		}
+	})
+}
+
+func TestBinaryStreams(t *testing.T) {
+	first := rbtree.New[string, float64]()
+	first.Insert("pi", 3.14)
+	first.Insert("e", 2.71)
+	second := rbtree.New[string, float64]()
+	second.Insert("zero", 0)
+
+	var buf bytes.Buffer
+	n1, err := first.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	n2, err := second.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if int(n1+n2) != buf.Len() {
+		t.Errorf("Expected WriteTo to report %d bytes, but got %d", buf.Len(), n1+n2)
+	}
+
+	reader := bytes.NewReader(buf.Bytes())
+	loadedFirst := rbtree.New[string, float64]()
+	if n, err := loadedFirst.ReadFrom(reader); err != nil || n != n1 {
+		t.Fatalf("Expected to read %d bytes, but got %d, %v", n1, n, err)
+	}
+	loadedSecond := rbtree.New[string, float64]()
+	if _, err := loadedSecond.ReadFrom(reader); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if v, ok := loadedFirst.Get("e"); !ok || v != 2.71 {
+		t.Errorf("Expected 2.71, but got %v, %v", v, ok)
+	}
+	if loadedSecond.Size() != 1 || !loadedSecond.ContainsKey("zero") {
+		t.Errorf("Expected the second map to hold only \"zero\", but got %v", collectKeys(loadedSecond.InOrder()))
+	}
+}
+
+func TestDefaultCodecPlainReader(t *testing.T) {
+	var buf bytes.Buffer
+	if err := rbtree.DefaultCodec[string]().Encode(&buf, "hello"); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	// io.MultiReader hides the io.ByteReader of the buffer.
+	s, err := rbtree.DefaultCodec[string]().Decode(io.MultiReader(&buf))
+	if err != nil || s != "hello" {
+		t.Errorf("Expected \"hello\", but got %q, %v", s, err)
+	}
+
+	buf.Reset()
+	if err := rbtree.DefaultCodec[int]().Encode(&buf, -300); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	n, err := rbtree.DefaultCodec[int]().Decode(io.MultiReader(&buf))
+	if err != nil || n != -300 {
+		t.Errorf("Expected -300, but got %d, %v", n, err)
+	}
+}
+
+func TestBinaryInvalidInput(t *testing.T) {
+	tree := createTestTree()
+	data, _ := tree.MarshalBinary()
+
+	t.Run("Bad Magic", func(t *testing.T) {
+		err := rbtree.New[int, string]().UnmarshalBinary([]byte("nope"))
+		if !errors.Is(err, rbtree.ErrInvalidFormat) {
+			t.Errorf("Expected ErrInvalidFormat, but got %v", err)
+		}
+	})
+
+	t.Run("Unknown Version", func(t *testing.T) {
+		corrupt := slices.Clone(data)
+		corrupt[4] = 99
+		err := rbtree.New[int, string]().UnmarshalBinary(corrupt)
+		if !errors.Is(err, rbtree.ErrInvalidFormat) {
+			t.Errorf("Expected ErrInvalidFormat, but got %v", err)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		loaded := rbtree.New[int, string]()
+		loaded.Insert(1, "kept")
+		err := loaded.UnmarshalBinary(data[:len(data)-1])
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Expected io.ErrUnexpectedEOF, but got %v", err)
+		}
+		if loaded.Size() != 1 {
+			t.Errorf("Expected a failed load to leave the map unchanged, but got size %d", loaded.Size())
+		}
+	})
+
+	t.Run("Trailing Bytes", func(t *testing.T) {
+		err := rbtree.New[int, string]().UnmarshalBinary(append(slices.Clone(data), 0))
+		if !errors.Is(err, rbtree.ErrInvalidFormat) {
+			t.Errorf("Expected ErrInvalidFormat, but got %v", err)
+		}
+	})
+
+	t.Run("Different Order", func(t *testing.T) {
+		desc := rbtree.NewWithComparator[int, string](func(a, b int) int { return b - a })
+		if err := desc.UnmarshalBinary(data); !errors.Is(err, rbtree.ErrNotSorted) {
+			t.Errorf("Expected ErrNotSorted when loading into a map with another order, but got %v", err)
+		}
+	})
+}
+
+func TestBinaryModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running binary serialization model test with seed: %d", seed)
+
+	tree := rbtree.New[int, []byte]()
+	for i := 0; i < 3000; i++ {
+		value := make([]byte, rng.Intn(20))
+		rng.Read(value)
+		tree.Insert(rng.Intn(1000000)-500000, value)
+	}
+
+	var buf bytes.Buffer
+	if _, err := tree.WriteTo(&buf); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	loaded := rbtree.New[int, []byte]()
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if loaded.Size() != tree.Size() {
+		t.Fatalf("Expected size %d, but got %d", tree.Size(), loaded.Size())
+	}
+	for k, v := range tree.InOrder() {
+		if actual, ok := loaded.Get(k); !ok || !bytes.Equal(v, actual) {
+			t.Fatalf("Expected value %v for key %d, but got %v, %v", v, k, actual, ok)
+		}
+	}
+
+	for i := 0; i < 3000; i++ {
+		key := rng.Intn(1000000) - 500000
+		if rng.Intn(2) == 0 {
+			tree.Insert(key, nil)
+			loaded.Insert(key, nil)
+		} else {
+			tree.Remove(key)
+			loaded.Remove(key)
+		}
+	}
+	if !slices.Equal(collectKeys(tree.InOrder()), collectKeys(loaded.InOrder())) {
+		t.Fatal("Expected the loaded map to stay in sync with the original after more updates")
+	}
+}
@@ -1,8 +1,8 @@
-﻿package tests
+package tests
 
 import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
 	"math/rand"
-	"rb-tree-map/internal/rbtree"
 	"testing"
 )
 
@@ -22,6 +22,19 @@ func BenchmarkBulkInsert(b *testing.B) {
 	}
 }
 
+func BenchmarkFromSorted(b *testing.B) {
+	keys := make([]int, b.N)
+	for i := 0; i < b.N; i++ {
+		keys[i] = i
+	}
+
+	b.ResetTimer()
+
+	if _, err := rbtree.FromSorted(keys, keys); err != nil {
+		b.Fatal(err)
+	}
+}
+
 func BenchmarkBulkRemove(b *testing.B) {
 	rng := rand.New(rand.NewSource(2))
 	keys := make([]int, b.N)
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"bytes"
+	"cmp"
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestComparatorTimeKeys(t *testing.T) {
+	tree := rbtree.NewWithComparator[time.Time, string](func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	offsets := []int{5, 1, 9, 3, 7}
+	for _, off := range offsets {
+		tree.Insert(base.Add(time.Duration(off)*time.Hour), "v")
+	}
+
+	sameInstant := base.Add(3 * time.Hour).In(time.FixedZone("UTC+3", 3*60*60))
+	tree.Insert(sameInstant, "updated")
+	if tree.Size() != len(offsets) {
+		t.Errorf("Expected size %d after inserting an equal instant in another zone, but got %d", len(offsets), tree.Size())
+	}
+	if v, ok := tree.Get(base.Add(3 * time.Hour)); !ok || v != "updated" {
+		t.Errorf("Expected Get to find the updated value, but got v=%q, ok=%v", v, ok)
+	}
+
+	prev := time.Time{}
+	for k := range tree.InOrder() {
+		if !prev.IsZero() && !prev.Before(k) {
+			t.Fatalf("Keys are not in ascending order: %v came before %v", prev, k)
+		}
+		prev = k
+	}
+
+	k, _, ok := tree.LowerBound(base.Add(4 * time.Hour))
+	if !ok || !k.Equal(base.Add(5*time.Hour)) {
+		t.Errorf("Expected LowerBound to find the 5h key, but got k=%v, ok=%v", k, ok)
+	}
+}
+
+type point struct {
+	x, y int
+}
+
+func TestComparatorStructKeys(t *testing.T) {
+	tree := rbtree.NewWithComparator[point, int](func(a, b point) int {
+		return cmp.Or(cmp.Compare(a.x, b.x), cmp.Compare(a.y, b.y))
+	})
+
+	points := []point{{2, 1}, {1, 5}, {2, 0}, {1, 1}, {0, 9}}
+	for i, p := range points {
+		tree.Insert(p, i)
+	}
+
+	expectedOrder := []point{{0, 9}, {1, 1}, {1, 5}, {2, 0}, {2, 1}}
+	actualOrder := make([]point, 0, tree.Size())
+	for k := range tree.InOrder() {
+		actualOrder = append(actualOrder, k)
+	}
+	if !slices.Equal(expectedOrder, actualOrder) {
+		t.Errorf("In-order traversal is incorrect.\nExpected: %v\nGot:      %v", expectedOrder, actualOrder)
+	}
+
+	tree.Remove(point{1, 5})
+	if tree.ContainsKey(point{1, 5}) {
+		t.Error("Key {1 5} should not be found after removal")
+	}
+
+	k, _, ok := tree.UpperBound(point{1, 1})
+	if !ok || k != (point{2, 0}) {
+		t.Errorf("Expected UpperBound({1 1}) to find {2 0}, but got k=%v, ok=%v", k, ok)
+	}
+}
+
+func TestComparatorByteSliceKeys(t *testing.T) {
+	tree := rbtree.NewWithComparator[[]byte, int](bytes.Compare)
+
+	tree.Insert([]byte("beta"), 2)
+	tree.Insert([]byte("alpha"), 1)
+	tree.Insert([]byte("gamma"), 3)
+	tree.Insert([]byte("alpha"), 10)
+
+	if tree.Size() != 3 {
+		t.Errorf("Expected size 3, but got %d", tree.Size())
+	}
+	if v, ok := tree.Get([]byte("alpha")); !ok || v != 10 {
+		t.Errorf("Expected Get(alpha) to return 10, but got v=%d, ok=%v", v, ok)
+	}
+
+	expectedOrder := []string{"alpha", "beta", "gamma"}
+	actualOrder := make([]string, 0, tree.Size())
+	for k := range tree.InOrder() {
+		actualOrder = append(actualOrder, string(k))
+	}
+	if !slices.Equal(expectedOrder, actualOrder) {
+		t.Errorf("In-order traversal is incorrect.\nExpected: %v\nGot:      %v", expectedOrder, actualOrder)
+	}
+}
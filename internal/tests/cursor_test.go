@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCursorEmptyTree(t *testing.T) {
+	tree := rbtree.New[int, string]()
+	c := tree.Cursor()
+
+	if c.Valid() {
+		t.Error("A new cursor should not be valid")
+	}
+	if c.First() || c.Last() || c.Seek(10) {
+		t.Error("Positioning a cursor on an empty tree should return false")
+	}
+	if c.Next() || c.Prev() {
+		t.Error("Stepping an invalid cursor should return false")
+	}
+}
+
+func TestCursorNavigation(t *testing.T) {
+	tree := createTestTree()
+	c := tree.Cursor()
+
+	forward := make([]int, 0)
+	for ok := c.First(); ok; ok = c.Next() {
+		forward = append(forward, c.Key())
+	}
+	if !slices.Equal([]int{10, 20, 30, 50, 60}, forward) {
+		t.Errorf("Forward walk is incorrect, got %v", forward)
+	}
+	if c.Valid() {
+		t.Error("Cursor should be invalid after stepping past the last entry")
+	}
+
+	backward := make([]int, 0)
+	for ok := c.Last(); ok; ok = c.Prev() {
+		backward = append(backward, c.Key())
+	}
+	if !slices.Equal([]int{60, 50, 30, 20, 10}, backward) {
+		t.Errorf("Backward walk is incorrect, got %v", backward)
+	}
+
+	if !c.Seek(35) || c.Key() != 50 || c.Value() != "v"+string(rune(50)) {
+		t.Errorf("Expected Seek(35) to land on 50, but got key %d", c.Key())
+	}
+	if !c.Prev() || c.Key() != 30 {
+		t.Errorf("Expected Prev after Seek(35) to land on 30, but got key %d", c.Key())
+	}
+	if !c.Next() || !c.Next() || c.Key() != 60 {
+		t.Errorf("Expected two Next calls to land on 60, but got key %d", c.Key())
+	}
+	if c.Seek(61) {
+		t.Error("Seek past the largest key should return false")
+	}
+}
+
+func TestCursorSetValue(t *testing.T) {
+	tree := createTestTree()
+	c := tree.Cursor()
+
+	if !c.Seek(20) {
+		t.Fatal("Seek(20) should find key 20")
+	}
+	c.SetValue("updated")
+
+	if v, _ := tree.Get(20); v != "updated" {
+		t.Errorf("Expected SetValue to update key 20, but Get returned %q", v)
+	}
+	if tree.Size() != 5 {
+		t.Errorf("SetValue should not change size, but got %d", tree.Size())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SetValue on an invalid cursor should panic")
+		}
+	}()
+	tree.Cursor().SetValue("boom")
+}
+
+func TestCursorModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running cursor model test with seed: %d", seed)
+
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 2000; i++ {
+		tree.Insert(rng.Intn(10000), i)
+	}
+	sorted := collectKeys(tree.InOrder())
+
+	c := tree.Cursor()
+	for i := 0; i < 200; i++ {
+		probe := rng.Intn(10000)
+		pos, _ := slices.BinarySearch(sorted, probe)
+		ok := c.Seek(probe)
+		if ok != (pos < len(sorted)) || (ok && c.Key() != sorted[pos]) {
+			t.Fatalf("Seek(%d) mismatch at position %d", probe, pos)
+		}
+		for step := 0; step < 20 && ok; step++ {
+			if rng.Intn(2) == 0 {
+				pos++
+				ok = c.Next()
+			} else {
+				pos--
+				ok = c.Prev()
+			}
+			expectedOk := pos >= 0 && pos < len(sorted)
+			if ok != expectedOk || (ok && c.Key() != sorted[pos]) {
+				t.Fatalf("Stepping mismatch at position %d", pos)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReversedCompareModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running reversed comparator model test with seed: %d", seed)
+
+	tree := rbtree.NewWithCompare[int, int](func(a, b int) bool { return a > b })
+	model := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(500)
+		if rng.Intn(3) == 0 {
+			tree.Remove(key)
+			delete(model, key)
+		} else {
+			tree.Insert(key, i)
+			model[key] = i
+		}
+	}
+
+	if tree.Size() != len(model) {
+		t.Fatalf("Size mismatch. Expected %d, got %d", len(model), tree.Size())
+	}
+
+	expectedOrder := make([]int, 0, len(model))
+	for k := range model {
+		expectedOrder = append(expectedOrder, k)
+	}
+	slices.Sort(expectedOrder)
+	slices.Reverse(expectedOrder)
+
+	actualOrder := make([]int, 0, tree.Size())
+	for k, v := range tree.InOrder() {
+		if v != model[k] {
+			t.Fatalf("Value mismatch for key %d. Expected %d, got %d", k, model[k], v)
+		}
+		actualOrder = append(actualOrder, k)
+	}
+	if !slices.Equal(expectedOrder, actualOrder) {
+		t.Errorf("In-order traversal should be descending.\nExpected: %v\nGot:      %v", expectedOrder, actualOrder)
+	}
+
+	k, _, ok := tree.LowerBound(expectedOrder[0] + 1)
+	if !ok || k != expectedOrder[0] {
+		t.Errorf("Expected LowerBound above the largest key to find %d, but got k=%v, ok=%v", expectedOrder[0], k, ok)
+	}
+}
+
+func TestCaseFoldedCompareModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running case-folded comparator model test with seed: %d", seed)
+
+	tree := rbtree.NewWithCompare[string, int](func(a, b string) bool {
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+	model := make(map[string]int)
+
+	words := []string{"apple", "banana", "cherry", "date", "elder", "fig", "grape"}
+	randomCase := func(s string) string {
+		b := []byte(s)
+		for i := range b {
+			if rng.Intn(2) == 0 {
+				b[i] -= 'a' - 'A'
+			}
+		}
+		return string(b)
+	}
+
+	for i := 0; i < 2000; i++ {
+		word := words[rng.Intn(len(words))]
+		key := randomCase(word)
+		if rng.Intn(4) == 0 {
+			tree.Remove(key)
+			delete(model, word)
+		} else {
+			tree.Insert(key, i)
+			model[word] = i
+		}
+
+		if tree.Size() != len(model) {
+			t.Fatalf("Size mismatch at step %d. Expected %d, got %d", i, len(model), tree.Size())
+		}
+	}
+
+	for word, expected := range model {
+		v, ok := tree.Get(strings.ToUpper(word))
+		if !ok || v != expected {
+			t.Errorf("Expected Get(%q) to return %d, but got v=%d, ok=%v", strings.ToUpper(word), expected, v, ok)
+		}
+	}
+
+	actualOrder := make([]string, 0, tree.Size())
+	for k := range tree.InOrder() {
+		actualOrder = append(actualOrder, strings.ToLower(k))
+	}
+	expectedOrder := make([]string, 0, len(model))
+	for word := range model {
+		expectedOrder = append(expectedOrder, word)
+	}
+	slices.Sort(expectedOrder)
+	if !slices.Equal(expectedOrder, actualOrder) {
+		t.Errorf("In-order traversal is incorrect.\nExpected: %v\nGot:      %v", expectedOrder, actualOrder)
+	}
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"errors"
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFromSorted(t *testing.T) {
+	t.Run("Builds Map", func(t *testing.T) {
+		keys := []int{1, 3, 5, 7, 9}
+		values := []string{"a", "b", "c", "d", "e"}
+		tree, err := rbtree.FromSorted(keys, values)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if tree.Size() != len(keys) {
+			t.Errorf("Expected size %d, but got %d", len(keys), tree.Size())
+		}
+		for i, k := range keys {
+			if v, ok := tree.Get(k); !ok || v != values[i] {
+				t.Errorf("Expected Get(%d) to return %q, but got %q, %v", k, values[i], v, ok)
+			}
+		}
+		if actual := collectKeys(tree.InOrder()); !slices.Equal(keys, actual) {
+			t.Errorf("Expected keys %v, but got %v", keys, actual)
+		}
+	})
+
+	t.Run("Empty Input", func(t *testing.T) {
+		tree, err := rbtree.FromSorted([]int{}, []int{})
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if tree.Size() != 0 {
+			t.Errorf("Expected an empty map, but got size %d", tree.Size())
+		}
+		tree.Insert(1, 1)
+		if !tree.ContainsKey(1) {
+			t.Error("Expected the map built from empty input to accept inserts")
+		}
+	})
+
+	t.Run("Unsorted Keys", func(t *testing.T) {
+		_, err := rbtree.FromSorted([]int{1, 5, 3}, []int{0, 0, 0})
+		if !errors.Is(err, rbtree.ErrNotSorted) {
+			t.Errorf("Expected ErrNotSorted, but got %v", err)
+		}
+	})
+
+	t.Run("Duplicate Keys", func(t *testing.T) {
+		_, err := rbtree.FromSorted([]int{1, 2, 2, 3}, []int{0, 0, 0, 0})
+		if !errors.Is(err, rbtree.ErrDuplicateKey) {
+			t.Errorf("Expected ErrDuplicateKey, but got %v", err)
+		}
+	})
+
+	t.Run("Length Mismatch", func(t *testing.T) {
+		if _, err := rbtree.FromSorted([]int{1, 2}, []int{0}); err == nil {
+			t.Error("Expected an error for keys and values of different lengths, but got nil")
+		}
+	})
+
+	t.Run("Custom Comparator", func(t *testing.T) {
+		desc := func(a, b int) int { return b - a }
+		tree, err := rbtree.FromSortedWithComparator(desc, []int{9, 5, 1}, []int{0, 0, 0})
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		tree.Insert(7, 0)
+		expected := []int{9, 7, 5, 1}
+		if actual := collectKeys(tree.InOrder()); !slices.Equal(expected, actual) {
+			t.Errorf("Expected keys %v, but got %v", expected, actual)
+		}
+	})
+}
+
+func TestFromSortedSeq(t *testing.T) {
+	source := rbtree.New[int, string]()
+	for _, k := range []int{4, 2, 8, 6} {
+		source.Insert(k, "v")
+	}
+
+	tree, err := rbtree.FromSortedSeq(source.InOrder())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	expected := []int{2, 4, 6, 8}
+	if actual := collectKeys(tree.InOrder()); !slices.Equal(expected, actual) {
+		t.Errorf("Expected keys %v, but got %v", expected, actual)
+	}
+
+	if _, err := rbtree.FromSortedSeq(source.Backward()); !errors.Is(err, rbtree.ErrNotSorted) {
+		t.Errorf("Expected ErrNotSorted for descending input, but got %v", err)
+	}
+}
+
+func TestFromSortedModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running FromSorted model test with seed: %d", seed)
+
+	model := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		model[rng.Intn(100000)] = rng.Int()
+	}
+	keys := slices.Sorted(maps.Keys(model))
+	values := make([]int, len(keys))
+	for i, k := range keys {
+		values[i] = model[k]
+	}
+
+	tree, err := rbtree.FromSorted(keys, values)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	for i := 0; i < 20000; i++ {
+		key := rng.Intn(100000)
+		if rng.Intn(2) == 0 {
+			model[key] = i
+			tree.Insert(key, i)
+		} else {
+			delete(model, key)
+			tree.Remove(key)
+		}
+	}
+
+	if tree.Size() != len(model) {
+		t.Fatalf("Expected size %d, but got %d", len(model), tree.Size())
+	}
+	for k, v := range tree.InOrder() {
+		if model[k] != v {
+			t.Fatalf("Expected value %d for key %d, but got %d", model[k], k, v)
+		}
+	}
+	if actual := collectKeys(tree.InOrder()); !slices.Equal(slices.Sorted(maps.Keys(model)), actual) {
+		t.Fatal("Keys after mixed operations are incorrect")
+	}
+}
@@ -1,7 +1,7 @@
-﻿package tests
+package tests
 
 import (
-	"rb-tree-map/internal/rbtree"
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
 	"testing"
 )
 
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type gobMessage struct {
+	Name  string
+	Index *rbtree.RBTreeMap[int, string]
+}
+
+type gobCaseInsensitive struct {
+	Names *rbtree.RBTreeMap[string, int]
+}
+
+func gobRoundTrip(t *testing.T, in, out any) error {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Expected no error encoding, but got %v", err)
+	}
+	return gob.NewDecoder(&buf).Decode(out)
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	t.Run("Struct Field", func(t *testing.T) {
+		in := gobMessage{Name: "test", Index: createTestTree()}
+		var out gobMessage
+		if err := gobRoundTrip(t, in, &out); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if out.Index.Size() != in.Index.Size() {
+			t.Fatalf("Expected size %d, but got %d", in.Index.Size(), out.Index.Size())
+		}
+		if !maps.Equal(maps.Collect(in.Index.InOrder()), maps.Collect(out.Index.InOrder())) {
+			t.Errorf("Expected %v, but got %v", maps.Collect(in.Index.InOrder()), maps.Collect(out.Index.InOrder()))
+		}
+		out.Index.Insert(40, "new")
+		expected := []int{10, 20, 30, 40, 50, 60}
+		if actual := collectKeys(out.Index.InOrder()); !slices.Equal(expected, actual) {
+			t.Errorf("Expected %v after inserting into the decoded map, but got %v", expected, actual)
+		}
+	})
+
+	t.Run("Empty Map", func(t *testing.T) {
+		in := gobMessage{Index: rbtree.New[int, string]()}
+		var out gobMessage
+		if err := gobRoundTrip(t, in, &out); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if out.Index == nil || out.Index.Size() != 0 {
+			t.Errorf("Expected an empty decoded map, but got %v", out.Index)
+		}
+	})
+
+	t.Run("SyncMap", func(t *testing.T) {
+		in := rbtree.NewSyncMap[string, float64]()
+		in.Insert("a", 1.5)
+		in.Insert("b", 2.5)
+		var out *rbtree.SyncMap[string, float64]
+		if err := gobRoundTrip(t, in, &out); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if v, ok := out.Get("b"); !ok || v != 2.5 {
+			t.Errorf("Expected 2.5, but got %v, %v", v, ok)
+		}
+	})
+}
+
+func TestGobCustomComparator(t *testing.T) {
+	foldLess := func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) }
+	in := gobCaseInsensitive{Names: rbtree.NewWithCompare[string, int](foldLess)}
+	in.Names.Insert("bob", 1)
+	in.Names.Insert("Alice", 2)
+	in.Names.Insert("carol", 3)
+
+	t.Run("Fails Without A Comparator", func(t *testing.T) {
+		var out gobCaseInsensitive
+		err := gobRoundTrip(t, in, &out)
+		if !errors.Is(err, rbtree.ErrNoComparator) {
+			t.Errorf("Expected ErrNoComparator, but got %v", err)
+		}
+	})
+
+	t.Run("Works With A Comparator", func(t *testing.T) {
+		out := gobCaseInsensitive{Names: rbtree.NewWithCompare[string, int](foldLess)}
+		if err := gobRoundTrip(t, in, &out); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		expected := []string{"Alice", "bob", "carol"}
+		if actual := collectKeys(out.Names.InOrder()); !slices.Equal(expected, actual) {
+			t.Errorf("Expected %v, but got %v", expected, actual)
+		}
+		if v, ok := out.Names.Get("BOB"); !ok || v != 1 {
+			t.Errorf("Expected the decoded map to keep the case-insensitive order, but Get(\"BOB\") returned %v, %v", v, ok)
+		}
+	})
+}
+
+func TestGobModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running gob model test with seed: %d", seed)
+
+	in := rbtree.New[int64, []int]()
+	for i := 0; i < 3000; i++ {
+		in.Insert(rng.Int63n(100000)-50000, []int{i, rng.Int()})
+	}
+	var out *rbtree.RBTreeMap[int64, []int]
+	if err := gobRoundTrip(t, in, &out); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if out.Size() != in.Size() {
+		t.Fatalf("Expected size %d, but got %d", in.Size(), out.Size())
+	}
+	for k, v := range in.InOrder() {
+		if actual, ok := out.Get(k); !ok || !slices.Equal(v, actual) {
+			t.Fatalf("Expected value %v for key %d, but got %v, %v", v, k, actual, ok)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+// collectIntervals sorts the intervals by Lo and then Hi, since Overlapping
+// yields them in no particular order.
+func collectIntervals[V any](seq iter.Seq2[rbtree.Interval[int], V]) []rbtree.Interval[int] {
+	result := make([]rbtree.Interval[int], 0)
+	for interval := range seq {
+		result = append(result, interval)
+	}
+	sortIntervals(result)
+	return result
+}
+
+func sortIntervals(intervals []rbtree.Interval[int]) {
+	slices.SortFunc(intervals, func(a, b rbtree.Interval[int]) int {
+		if a.Lo != b.Lo {
+			return a.Lo - b.Lo
+		}
+		return a.Hi - b.Hi
+	})
+}
+
+func TestIntervalTreeBasic(t *testing.T) {
+	tree := rbtree.NewIntervalTree[int, string]()
+	tree.Insert(15, 20, "a")
+	tree.Insert(10, 30, "b")
+	tree.Insert(17, 19, "c")
+	tree.Insert(5, 20, "d")
+	tree.Insert(12, 15, "e")
+	tree.Insert(30, 40, "f")
+
+	t.Run("Size And Get", func(t *testing.T) {
+		if tree.Size() != 6 {
+			t.Errorf("Expected size 6, but got %d", tree.Size())
+		}
+		if v, ok := tree.Get(17, 19); !ok || v != "c" {
+			t.Errorf("Expected Get(17, 19) to return c, but got %q, %v", v, ok)
+		}
+		if _, ok := tree.Get(17, 20); ok {
+			t.Error("Expected Get(17, 20) to find nothing")
+		}
+	})
+
+	t.Run("Overlapping", func(t *testing.T) {
+		expected := []rbtree.Interval[int]{{Lo: 5, Hi: 20}, {Lo: 10, Hi: 30}, {Lo: 12, Hi: 15}, {Lo: 15, Hi: 20}}
+		if actual := collectIntervals(tree.Overlapping(14, 16)); !slices.Equal(expected, actual) {
+			t.Errorf("Expected %v, but got %v", expected, actual)
+		}
+	})
+
+	t.Run("Endpoints Are Inclusive", func(t *testing.T) {
+		expected := []rbtree.Interval[int]{{Lo: 10, Hi: 30}, {Lo: 30, Hi: 40}}
+		if actual := collectIntervals(tree.Containing(30)); !slices.Equal(expected, actual) {
+			t.Errorf("Expected %v, but got %v", expected, actual)
+		}
+	})
+
+	t.Run("Any", func(t *testing.T) {
+		if !tree.AnyContaining(40) {
+			t.Error("Expected AnyContaining(40) to be true")
+		}
+		if tree.AnyContaining(41) || tree.AnyContaining(4) {
+			t.Error("Expected no interval to contain 4 or 41")
+		}
+		if !tree.AnyOverlapping(0, 5) {
+			t.Error("Expected AnyOverlapping(0, 5) to be true")
+		}
+	})
+
+	t.Run("Replace And Remove", func(t *testing.T) {
+		tree.Insert(30, 40, "g")
+		if v, _ := tree.Get(30, 40); v != "g" {
+			t.Errorf("Expected the value to be replaced with g, but got %q", v)
+		}
+		tree.Remove(30, 40)
+		tree.Remove(10, 30)
+		if tree.AnyContaining(30) {
+			t.Error("Expected no interval to contain 30 after removing [10, 30] and [30, 40]")
+		}
+	})
+
+	t.Run("Invalid Interval", func(t *testing.T) {
+		expectPanic(t, "Insert with Lo greater than Hi", func() {
+			tree.Insert(5, 4, "x")
+		})
+	})
+}
+
+func TestIntervalTreeTimeRanges(t *testing.T) {
+	tree := rbtree.NewIntervalTreeWithComparator[time.Time, string](func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tree.Insert(day.Add(9*time.Hour), day.Add(10*time.Hour), "standup")
+	tree.Insert(day.Add(13*time.Hour), day.Add(15*time.Hour), "review")
+
+	var found []string
+	for _, name := range tree.Containing(day.Add(14 * time.Hour)) {
+		found = append(found, name)
+	}
+	if !slices.Equal([]string{"review"}, found) {
+		t.Errorf("Expected [review], but got %v", found)
+	}
+}
+
+func TestIntervalTreeModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running interval tree model test with seed: %d", seed)
+
+	tree := rbtree.NewIntervalTree[int, int]()
+	model := make(map[rbtree.Interval[int]]int)
+
+	for i := 0; i < 5000; i++ {
+		lo := rng.Intn(2000) - 1000
+		interval := rbtree.Interval[int]{Lo: lo, Hi: lo + rng.Intn(100)}
+		if rng.Intn(3) == 0 {
+			delete(model, interval)
+			tree.Remove(interval.Lo, interval.Hi)
+		} else {
+			model[interval] = i
+			tree.Insert(interval.Lo, interval.Hi, i)
+		}
+
+		if i%50 != 0 {
+			continue
+		}
+		qlo := rng.Intn(2200) - 1100
+		qhi := qlo + rng.Intn(30)
+		expected := make([]rbtree.Interval[int], 0)
+		for interval := range model {
+			if interval.Lo <= qhi && interval.Hi >= qlo {
+				expected = append(expected, interval)
+			}
+		}
+		sortIntervals(expected)
+
+		actual := make([]rbtree.Interval[int], 0)
+		for interval, v := range tree.Overlapping(qlo, qhi) {
+			if model[interval] != v {
+				t.Fatalf("Expected value %d for %v, but got %d", model[interval], interval, v)
+			}
+			actual = append(actual, interval)
+		}
+		sortIntervals(actual)
+		if !slices.Equal(expected, actual) {
+			t.Fatalf("Step %d: expected %v overlapping [%d, %d], but got %v", i, expected, qlo, qhi, actual)
+		}
+		if tree.AnyOverlapping(qlo, qhi) != (len(expected) > 0) {
+			t.Fatalf("Step %d: expected AnyOverlapping(%d, %d) to be %v", i, qlo, qhi, len(expected) > 0)
+		}
+	}
+
+	if tree.Size() != len(model) {
+		t.Fatalf("Expected size %d, but got %d", len(model), tree.Size())
+	}
+}
+
+func TestIntervalTreeHeapModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running interval tree heap model test with seed: %d", seed)
+
+	for round := 0; round < 10; round++ {
+		tree := rbtree.NewIntervalTree[int, int]()
+		model := make(map[rbtree.Interval[int]]int)
+		span := 1 + rng.Intn(200)
+
+		for i := 0; i < 2000; i++ {
+			lo := rng.Intn(span)
+			interval := rbtree.Interval[int]{Lo: lo, Hi: lo + rng.Intn(span)}
+			if rng.Intn(2) == 0 {
+				delete(model, interval)
+				tree.Remove(interval.Lo, interval.Hi)
+			} else {
+				model[interval] = i
+				tree.Insert(interval.Lo, interval.Hi, i)
+			}
+
+			qlo := rng.Intn(2*span) - span/2
+			qhi := qlo + rng.Intn(span)
+			expected := make([]rbtree.Interval[int], 0)
+			for interval := range model {
+				if interval.Lo <= qhi && interval.Hi >= qlo {
+					expected = append(expected, interval)
+				}
+			}
+			sortIntervals(expected)
+			if actual := collectIntervals(tree.Overlapping(qlo, qhi)); !slices.Equal(expected, actual) {
+				t.Fatalf("Round %d, step %d: expected %v overlapping [%d, %d], but got %v", round, i, expected, qlo, qhi, actual)
+			}
+		}
+
+		all := make([]rbtree.Interval[int], 0, len(model))
+		for interval := range model {
+			all = append(all, interval)
+		}
+		sortIntervals(all)
+		if actual := collectIntervals(tree.Overlapping(-1, 3*span)); !slices.Equal(all, actual) {
+			t.Fatalf("Round %d: expected every interval to overlap a range covering them all", round)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"maps"
+	"math/rand"
+	"net/netip"
+	"slices"
+	"testing"
+	"time"
+)
+
+type userID string
+
+func TestMarshalJSON(t *testing.T) {
+	t.Run("String Keys Become An Object In Order", func(t *testing.T) {
+		tree := rbtree.New[string, int]()
+		for i, k := range []string{"pear", "apple", "zucchini", "fig"} {
+			tree.Insert(k, i)
+		}
+		data, err := json.Marshal(tree)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		expected := `{"apple":1,"fig":3,"pear":0,"zucchini":2}`
+		if string(data) != expected {
+			t.Errorf("Expected %s, but got %s", expected, data)
+		}
+	})
+
+	t.Run("Named String Keys", func(t *testing.T) {
+		tree := rbtree.New[userID, bool]()
+		tree.Insert("bob", true)
+		tree.Insert("alice", false)
+		data, _ := json.Marshal(tree)
+		expected := `{"alice":false,"bob":true}`
+		if string(data) != expected {
+			t.Errorf("Expected %s, but got %s", expected, data)
+		}
+	})
+
+	t.Run("TextMarshaler Keys Become An Object", func(t *testing.T) {
+		tree := rbtree.NewWithComparator[netip.Addr, string](func(a, b netip.Addr) int { return a.Compare(b) })
+		tree.Insert(netip.MustParseAddr("10.0.0.2"), "b")
+		tree.Insert(netip.MustParseAddr("10.0.0.1"), "a")
+		data, err := json.Marshal(tree)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		expected := `{"10.0.0.1":"a","10.0.0.2":"b"}`
+		if string(data) != expected {
+			t.Errorf("Expected %s, but got %s", expected, data)
+		}
+	})
+
+	t.Run("Other Keys Become Pairs", func(t *testing.T) {
+		tree := rbtree.New[int, string]()
+		for _, k := range []int{30, -5, 10} {
+			tree.Insert(k, "v")
+		}
+		data, err := json.Marshal(tree)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		expected := `[[-5,"v"],[10,"v"],[30,"v"]]`
+		if string(data) != expected {
+			t.Errorf("Expected %s, but got %s", expected, data)
+		}
+	})
+
+	t.Run("Empty Maps", func(t *testing.T) {
+		if data, _ := json.Marshal(rbtree.New[string, int]()); string(data) != "{}" {
+			t.Errorf("Expected {}, but got %s", data)
+		}
+		if data, _ := json.Marshal(rbtree.New[int, int]()); string(data) != "[]" {
+			t.Errorf("Expected [], but got %s", data)
+		}
+	})
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Run("Object", func(t *testing.T) {
+		tree := rbtree.New[string, int]()
+		tree.Insert("stale", 0)
+		if err := json.Unmarshal([]byte(`{"b":2,"a":1,"c":3,"a":4}`), tree); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		expected := map[string]int{"a": 4, "b": 2, "c": 3}
+		if actual := maps.Collect(tree.InOrder()); !maps.Equal(expected, actual) {
+			t.Errorf("Expected %v, but got %v", expected, actual)
+		}
+	})
+
+	t.Run("Pairs", func(t *testing.T) {
+		tree := rbtree.New[float64, string]()
+		if err := json.Unmarshal([]byte(`[[2.5,"x"],[-1,"y"]]`), tree); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		expected := []float64{-1, 2.5}
+		if actual := collectKeys(tree.InOrder()); !slices.Equal(expected, actual) {
+			t.Errorf("Expected %v, but got %v", expected, actual)
+		}
+	})
+
+	t.Run("Struct Field", func(t *testing.T) {
+		var doc struct {
+			Scores *rbtree.RBTreeMap[userID, int] `json:"scores"`
+		}
+		if err := json.Unmarshal([]byte(`{"scores":{"carol":3,"alice":1}}`), &doc); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if k, v, ok := doc.Scores.Min(); !ok || k != "alice" || v != 1 {
+			t.Errorf("Expected the smallest entry to be alice=1, but got %v=%v, %v", k, v, ok)
+		}
+		doc.Scores.Insert("bob", 2)
+		expected := []userID{"alice", "bob", "carol"}
+		if actual := collectKeys(doc.Scores.InOrder()); !slices.Equal(expected, actual) {
+			t.Errorf("Expected %v, but got %v", expected, actual)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tree := rbtree.New[int, int]()
+		inputs := []string{`{"1":1}`, `[[1]]`, `[[1,2,3]]`, `"text"`, `[["a",1]]`, `[[1,1]`}
+		for _, input := range inputs {
+			if err := json.Unmarshal([]byte(input), tree); err == nil {
+				t.Errorf("Expected an error for %s, but got nil", input)
+			}
+		}
+	})
+}
+
+func TestJSONModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running JSON model test with seed: %d", seed)
+
+	byName := rbtree.New[string, int]()
+	byNumber := rbtree.New[int, []string]()
+	for i := 0; i < 2000; i++ {
+		key := rng.Intn(10000)
+		byName.Insert(time.Duration(key).String(), key)
+		byNumber.Insert(key-5000, []string{time.Duration(key).String()})
+	}
+
+	data, err := json.Marshal(byName)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	loadedByName := rbtree.New[string, int]()
+	if err := json.Unmarshal(data, loadedByName); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !maps.Equal(maps.Collect(byName.InOrder()), maps.Collect(loadedByName.InOrder())) {
+		t.Fatal("Expected the object round trip to keep every entry")
+	}
+
+	data, err = json.Marshal(byNumber)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	loadedByNumber := rbtree.New[int, []string]()
+	if err := json.Unmarshal(data, loadedByNumber); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if loadedByNumber.Size() != byNumber.Size() {
+		t.Fatalf("Expected size %d, but got %d", byNumber.Size(), loadedByNumber.Size())
+	}
+	for k, v := range byNumber.InOrder() {
+		if actual, ok := loadedByNumber.Get(k); !ok || !slices.Equal(v, actual) {
+			t.Fatalf("Expected value %v for key %d, but got %v, %v", v, k, actual, ok)
+		}
+	}
+}
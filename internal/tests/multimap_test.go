@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMultiMapBasic(t *testing.T) {
+	events := rbtree.NewMultiMap[int, string]()
+	events.Insert(20, "b1")
+	events.Insert(10, "a1")
+	events.Insert(20, "b2")
+	events.Insert(30, "c1")
+	events.Insert(20, "b3")
+
+	t.Run("Insertion Order", func(t *testing.T) {
+		if events.Size() != 5 {
+			t.Errorf("Expected size 5, but got %d", events.Size())
+		}
+		expected := []string{"b1", "b2", "b3"}
+		if actual := slices.Collect(events.GetAll(20)); !slices.Equal(expected, actual) {
+			t.Errorf("Expected %v, but got %v", expected, actual)
+		}
+		if count := events.Count(20); count != 3 {
+			t.Errorf("Expected Count(20) to be 3, but got %d", count)
+		}
+		if count := events.Count(25); count != 0 {
+			t.Errorf("Expected Count(25) to be 0, but got %d", count)
+		}
+	})
+
+	t.Run("InOrder", func(t *testing.T) {
+		var values []string
+		for _, v := range events.InOrder() {
+			values = append(values, v)
+		}
+		expected := []string{"a1", "b1", "b2", "b3", "c1"}
+		if !slices.Equal(expected, values) {
+			t.Errorf("Expected %v, but got %v", expected, values)
+		}
+	})
+
+	t.Run("RemoveOne", func(t *testing.T) {
+		if !events.RemoveOne(20) {
+			t.Fatal("Expected RemoveOne(20) to report a removal")
+		}
+		expected := []string{"b2", "b3"}
+		if actual := slices.Collect(events.GetAll(20)); !slices.Equal(expected, actual) {
+			t.Errorf("Expected the oldest value to be removed, leaving %v, but got %v", expected, actual)
+		}
+		if events.RemoveOne(25) {
+			t.Error("Expected RemoveOne on a missing key to report false")
+		}
+	})
+
+	t.Run("RemoveAll", func(t *testing.T) {
+		if removed := events.RemoveAll(20); removed != 2 {
+			t.Errorf("Expected RemoveAll(20) to remove 2 values, but got %d", removed)
+		}
+		if events.ContainsKey(20) || events.Size() != 2 {
+			t.Errorf("Expected key 20 to be gone and size 2, but got size %d", events.Size())
+		}
+		if removed := events.RemoveAll(20); removed != 0 {
+			t.Errorf("Expected a second RemoveAll(20) to remove nothing, but got %d", removed)
+		}
+		events.Insert(20, "b4")
+		if actual := slices.Collect(events.GetAll(20)); !slices.Equal([]string{"b4"}, actual) {
+			t.Errorf("Expected [b4] after inserting again, but got %v", actual)
+		}
+	})
+
+	t.Run("Modification During Iteration", func(t *testing.T) {
+		expectPanic(t, "Insert during GetAll", func() {
+			for range events.GetAll(10) {
+				events.Insert(10, "a2")
+			}
+		})
+	})
+}
+
+func TestMultiMapEqualRange(t *testing.T) {
+	names := rbtree.NewMultiMapWithComparator[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	names.Insert("Bob", 1)
+	names.Insert("alice", 2)
+	names.Insert("BOB", 3)
+	names.Insert("bob", 4)
+
+	var keys []string
+	var values []int
+	for k, v := range names.EqualRange("bOb") {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !slices.Equal([]string{"Bob", "BOB", "bob"}, keys) {
+		t.Errorf("Expected the keys as inserted, but got %v", keys)
+	}
+	if !slices.Equal([]int{1, 3, 4}, values) {
+		t.Errorf("Expected [1 3 4], but got %v", values)
+	}
+}
+
+func TestMultiMapModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running multimap model test with seed: %d", seed)
+
+	multi := rbtree.NewMultiMap[int, int]()
+	model := make(map[int][]int)
+	size := 0
+
+	for i := 0; i < 20000; i++ {
+		key := rng.Intn(200)
+		switch op := rng.Intn(10); {
+		case op < 6:
+			multi.Insert(key, i)
+			model[key] = append(model[key], i)
+			size++
+		case op < 9:
+			removed := multi.RemoveOne(key)
+			if removed != (len(model[key]) > 0) {
+				t.Fatalf("Step %d: RemoveOne(%d) returned %v with %d values stored", i, key, removed, len(model[key]))
+			}
+			if removed {
+				model[key] = model[key][1:]
+				size--
+			}
+		default:
+			if removed := multi.RemoveAll(key); removed != len(model[key]) {
+				t.Fatalf("Step %d: expected RemoveAll(%d) to remove %d values, but got %d", i, key, len(model[key]), removed)
+			}
+			size -= len(model[key])
+			delete(model, key)
+		}
+
+		if multi.Size() != size {
+			t.Fatalf("Step %d: expected size %d, but got %d", i, size, multi.Size())
+		}
+		probe := rng.Intn(200)
+		if multi.Count(probe) != len(model[probe]) {
+			t.Fatalf("Step %d: expected Count(%d) to be %d, but got %d", i, probe, len(model[probe]), multi.Count(probe))
+		}
+		if actual := slices.Collect(multi.GetAll(probe)); !slices.Equal(model[probe], actual) {
+			t.Fatalf("Step %d: expected GetAll(%d) to be %v, but got %v", i, probe, model[probe], actual)
+		}
+	}
+
+	prevKey := -1
+	for k := range multi.InOrder() {
+		if k < prevKey {
+			t.Fatalf("Keys are not in ascending order: %d came after %d", k, prevKey)
+		}
+		prevKey = k
+	}
+}
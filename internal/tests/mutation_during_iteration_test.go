@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func expectPanic(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s should panic", name)
+		}
+	}()
+	f()
+}
+
+func TestInOrderPanicsOnStructuralModification(t *testing.T) {
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+
+	expectPanic(t, "Remove inside InOrder", func() {
+		for k := range tree.InOrder() {
+			tree.Remove(k)
+		}
+	})
+
+	expectPanic(t, "Insert of a new key inside Backward", func() {
+		for k := range tree.Backward() {
+			tree.Insert(k+100, k)
+		}
+	})
+
+	expectPanic(t, "Remove inside Range", func() {
+		for k := range tree.Range(0, 1000) {
+			tree.Remove(k)
+		}
+	})
+}
+
+func TestInOrderAllowsValueUpdates(t *testing.T) {
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+
+	for k, v := range tree.InOrder() {
+		tree.Insert(k, v*10)
+	}
+	for k, v := range tree.InOrder() {
+		if v != k*10 {
+			t.Errorf("Expected value %d for key %d, but got %d", k*10, k, v)
+		}
+	}
+
+	for k := range tree.InOrder() {
+		tree.Remove(k)
+		break
+	}
+	if tree.Size() != 9 {
+		t.Errorf("Expected size 9 after removing and breaking, but got %d", tree.Size())
+	}
+}
+
+func TestCursorDelete(t *testing.T) {
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 20; i++ {
+		tree.Insert(i, i)
+	}
+
+	c := tree.Cursor()
+	for ok := c.First(); ok; {
+		if c.Key()%2 == 0 {
+			c.Delete()
+			ok = c.Valid()
+		} else {
+			ok = c.Next()
+		}
+	}
+
+	expected := []int{1, 3, 5, 7, 9, 11, 13, 15, 17, 19}
+	actual := collectKeys(tree.InOrder())
+	if !slices.Equal(expected, actual) {
+		t.Errorf("Expected only odd keys to remain.\nExpected: %v\nGot:      %v", expected, actual)
+	}
+	if tree.Size() != len(expected) {
+		t.Errorf("Expected size %d, but got %d", len(expected), tree.Size())
+	}
+
+	c.Last()
+	c.Delete()
+	if c.Valid() {
+		t.Error("Cursor should be invalid after deleting the last entry")
+	}
+	expectPanic(t, "Delete on an invalid cursor", c.Delete)
+}
+
+func TestCursorPanicsAfterExternalModification(t *testing.T) {
+	tree := createTestTree()
+	c := tree.Cursor()
+	c.Seek(20)
+
+	tree.Remove(30)
+	expectPanic(t, "Next after an external Remove", func() { c.Next() })
+
+	c.Seek(20)
+	tree.Insert(25, "new")
+	expectPanic(t, "Delete after an external Insert", c.Delete)
+
+	c.Seek(20)
+	if !c.Next() || c.Key() != 25 {
+		t.Errorf("Re-seeking should make the cursor usable again, but got key %d", c.Key())
+	}
+}
+
+func TestCursorDeleteModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running cursor delete model test with seed: %d", seed)
+
+	tree := rbtree.New[int, int]()
+	trackingMap := make(map[int]struct{})
+	for i := 0; i < 3000; i++ {
+		key := rng.Intn(10000)
+		tree.Insert(key, key)
+		trackingMap[key] = struct{}{}
+	}
+
+	c := tree.Cursor()
+	for ok := c.First(); ok; {
+		if rng.Intn(3) == 0 {
+			delete(trackingMap, c.Key())
+			c.Delete()
+			ok = c.Valid()
+		} else {
+			ok = c.Next()
+		}
+	}
+
+	expected := getKeysFromMap(trackingMap)
+	slices.Sort(expected)
+	actual := collectKeys(tree.InOrder())
+	if !slices.Equal(expected, actual) {
+		t.Fatalf("Tree content is incorrect after deleting through a cursor")
+	}
+	if tree.Size() != len(expected) {
+		t.Errorf("Expected size %d, but got %d", len(expected), tree.Size())
+	}
+}
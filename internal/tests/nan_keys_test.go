@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNaNKeyBasic(t *testing.T) {
+	tree := rbtree.New[float64, string]()
+	nan := math.NaN()
+
+	tree.Insert(1.5, "a")
+	tree.Insert(nan, "nan")
+	tree.Insert(-3, "b")
+	tree.Insert(math.NaN(), "nan2")
+
+	if tree.Size() != 3 {
+		t.Fatalf("Expected NaN to be inserted only once, size should be 3, but got %d", tree.Size())
+	}
+
+	v, ok := tree.Get(nan)
+	if !ok || v != "nan2" {
+		t.Errorf("Expected Get(NaN) to return 'nan2', but got v=%q, ok=%v", v, ok)
+	}
+
+	k, _, ok := tree.LowerBound(math.Inf(-1))
+	if !ok || k != -3 {
+		t.Errorf("Expected LowerBound(-Inf) to skip NaN and find -3, but got k=%v, ok=%v", k, ok)
+	}
+
+	k, _, ok = tree.LowerBound(nan)
+	if !ok || !math.IsNaN(k) {
+		t.Errorf("Expected LowerBound(NaN) to find NaN, but got k=%v, ok=%v", k, ok)
+	}
+
+	k, _, ok = tree.UpperBound(nan)
+	if !ok || k != -3 {
+		t.Errorf("Expected UpperBound(NaN) to find -3, but got k=%v, ok=%v", k, ok)
+	}
+
+	first := true
+	for k := range tree.InOrder() {
+		if first && !math.IsNaN(k) {
+			t.Errorf("Expected NaN to be the first key in order, but got %v", k)
+		}
+		first = false
+	}
+
+	tree.Remove(nan)
+	if tree.ContainsKey(nan) {
+		t.Error("NaN should not be found after removal")
+	}
+	if tree.Size() != 2 {
+		t.Errorf("Expected size 2 after removing NaN, but got %d", tree.Size())
+	}
+}
+
+func TestSignedZeroKeys(t *testing.T) {
+	tree := rbtree.New[float64, int]()
+	negZero := math.Copysign(0, -1)
+
+	tree.Insert(0, 1)
+	tree.Insert(negZero, 2)
+
+	if tree.Size() != 1 {
+		t.Fatalf("Expected -0 and +0 to be the same key, size should be 1, but got %d", tree.Size())
+	}
+	if v, ok := tree.Get(0); !ok || v != 2 {
+		t.Errorf("Expected Get(0) to return 2, but got v=%d, ok=%v", v, ok)
+	}
+
+	tree.Remove(negZero)
+	if tree.Size() != 0 {
+		t.Errorf("Expected Remove(-0) to remove the +0 key, but size is %d", tree.Size())
+	}
+}
+
+func TestNaNInsertRemoveModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running NaN insert/remove model test with seed: %d", seed)
+
+	tree := rbtree.New[float64, int]()
+	model := make(map[float64]int)
+	nanValue, hasNaN := 0, false
+
+	randomKey := func() float64 {
+		switch rng.Intn(10) {
+		case 0:
+			return math.NaN()
+		case 1:
+			return math.Copysign(0, -1)
+		default:
+			return float64(rng.Intn(200) - 100)
+		}
+	}
+
+	for i := 0; i < 5000; i++ {
+		key := randomKey()
+		if rng.Intn(3) == 0 {
+			tree.Remove(key)
+			if math.IsNaN(key) {
+				hasNaN = false
+			} else {
+				delete(model, key)
+			}
+		} else {
+			tree.Insert(key, i)
+			if math.IsNaN(key) {
+				nanValue, hasNaN = i, true
+			} else {
+				model[key] = i
+			}
+		}
+
+		expectedSize := len(model)
+		if hasNaN {
+			expectedSize++
+		}
+		if tree.Size() != expectedSize {
+			t.Fatalf("Size mismatch at step %d. Expected %d, got %d", i, expectedSize, tree.Size())
+		}
+	}
+
+	if v, ok := tree.Get(math.NaN()); ok != hasNaN || (ok && v != nanValue) {
+		t.Errorf("Get(NaN) mismatch. Expected v=%d, ok=%v, got v=%d, ok=%v", nanValue, hasNaN, v, ok)
+	}
+
+	expectedOrder := make([]float64, 0, len(model)+1)
+	if hasNaN {
+		expectedOrder = append(expectedOrder, math.NaN())
+	}
+	for k := range model {
+		expectedOrder = append(expectedOrder, k)
+	}
+	slices.Sort(expectedOrder)
+
+	actualOrder := make([]float64, 0, tree.Size())
+	for k, v := range tree.InOrder() {
+		if !math.IsNaN(k) && v != model[k] {
+			t.Fatalf("Value mismatch for key %v. Expected %d, got %d", k, model[k], v)
+		}
+		actualOrder = append(actualOrder, k)
+	}
+
+	sameKey := func(a, b float64) bool {
+		return a == b || (math.IsNaN(a) && math.IsNaN(b))
+	}
+	if !slices.EqualFunc(expectedOrder, actualOrder, sameKey) {
+		t.Errorf("In-order traversal is incorrect.\nExpected: %v\nGot:      %v", expectedOrder, actualOrder)
+	}
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNavigationQueries(t *testing.T) {
+	tree := createTestTree()
+
+	type query func(int) (int, string, bool)
+	cases := []struct {
+		name   string
+		query  query
+		key    int
+		want   int
+		wantOk bool
+	}{
+		{"Floor Exact", tree.Floor, 30, 30, true},
+		{"Floor Between", tree.Floor, 35, 30, true},
+		{"Floor Below Min", tree.Floor, 5, 0, false},
+		{"Floor Above Max", tree.Floor, 100, 60, true},
+		{"Lower Exact", tree.Lower, 30, 20, true},
+		{"Lower Between", tree.Lower, 35, 30, true},
+		{"Lower At Min", tree.Lower, 10, 0, false},
+		{"Ceiling Exact", tree.Ceiling, 30, 30, true},
+		{"Ceiling Between", tree.Ceiling, 35, 50, true},
+		{"Ceiling Above Max", tree.Ceiling, 61, 0, false},
+		{"Higher Exact", tree.Higher, 30, 50, true},
+		{"Higher Below Min", tree.Higher, 5, 10, true},
+		{"Higher At Max", tree.Higher, 60, 0, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			k, v, ok := tc.query(tc.key)
+			if ok != tc.wantOk || k != tc.want {
+				t.Errorf("Expected k=%d, ok=%v, but got k=%d, ok=%v", tc.want, tc.wantOk, k, ok)
+			}
+			if ok && v != "v"+string(rune(k)) {
+				t.Errorf("Unexpected value %q for key %d", v, k)
+			}
+		})
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	emptyTree := rbtree.New[int, string]()
+	if _, _, ok := emptyTree.Min(); ok {
+		t.Error("Min on an empty tree should return ok=false")
+	}
+	if _, _, ok := emptyTree.Max(); ok {
+		t.Error("Max on an empty tree should return ok=false")
+	}
+
+	tree := createTestTree()
+	if k, v, ok := tree.Min(); !ok || k != 10 || v != "v"+string(rune(10)) {
+		t.Errorf("Expected Min to be 10, but got k=%v, v=%v, ok=%v", k, v, ok)
+	}
+	if k, v, ok := tree.Max(); !ok || k != 60 || v != "v"+string(rune(60)) {
+		t.Errorf("Expected Max to be 60, but got k=%v, v=%v, ok=%v", k, v, ok)
+	}
+}
+
+func TestFloorAndLowerModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running floor/lower model test with seed: %d", seed)
+
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 2000; i++ {
+		tree.Insert(rng.Intn(10000), i)
+	}
+	sorted := collectKeys(tree.InOrder())
+
+	for i := 0; i < 500; i++ {
+		probe := rng.Intn(10200) - 100
+		pos, found := slices.BinarySearch(sorted, probe)
+
+		floorPos := pos - 1
+		if found {
+			floorPos = pos
+		}
+		k, _, ok := tree.Floor(probe)
+		if ok != (floorPos >= 0) || (ok && k != sorted[floorPos]) {
+			t.Fatalf("Floor(%d) mismatch: got k=%d, ok=%v", probe, k, ok)
+		}
+
+		k, _, ok = tree.Lower(probe)
+		if ok != (pos > 0) || (ok && k != sorted[pos-1]) {
+			t.Fatalf("Lower(%d) mismatch: got k=%d, ok=%v", probe, k, ok)
+		}
+	}
+}
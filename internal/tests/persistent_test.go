@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPersistentBasic(t *testing.T) {
+	empty := rbtree.NewPersistent[string, int]()
+	v1 := empty.Insert("apple", 10)
+	v2 := v1.Insert("banana", 20)
+	v3 := v2.Insert("apple", 99)
+	v4 := v3.Remove("banana")
+
+	if empty.Size() != 0 || v1.Size() != 1 || v2.Size() != 2 || v3.Size() != 2 || v4.Size() != 1 {
+		t.Errorf("Unexpected sizes: %d %d %d %d %d", empty.Size(), v1.Size(), v2.Size(), v3.Size(), v4.Size())
+	}
+	if empty.ContainsKey("apple") {
+		t.Error("The empty version should not change after Insert")
+	}
+	if v, _ := v2.Get("apple"); v != 10 {
+		t.Errorf("Expected v2 to keep apple=10, but got %d", v)
+	}
+	if v, _ := v3.Get("apple"); v != 99 {
+		t.Errorf("Expected v3 to have apple=99, but got %d", v)
+	}
+	if !v3.ContainsKey("banana") {
+		t.Error("v3 should still contain banana after v4 removed it")
+	}
+	if v4.ContainsKey("banana") {
+		t.Error("v4 should not contain banana")
+	}
+	if v4.Remove("missing") != v4 {
+		t.Error("Removing a missing key should return the same version")
+	}
+}
+
+func TestPersistentBounds(t *testing.T) {
+	m := rbtree.NewPersistent[int, string]()
+	for _, k := range []int{30, 20, 50, 10, 60} {
+		m = m.Insert(k, "v"+string(rune(k)))
+	}
+
+	if k, _, ok := m.LowerBound(35); !ok || k != 50 {
+		t.Errorf("Expected LowerBound(35) to find 50, but got k=%v, ok=%v", k, ok)
+	}
+	if k, _, ok := m.LowerBound(30); !ok || k != 30 {
+		t.Errorf("Expected LowerBound(30) to find 30, but got k=%v, ok=%v", k, ok)
+	}
+	if k, _, ok := m.UpperBound(30); !ok || k != 50 {
+		t.Errorf("Expected UpperBound(30) to find 50, but got k=%v, ok=%v", k, ok)
+	}
+	if _, _, ok := m.UpperBound(60); ok {
+		t.Error("UpperBound for the maximum element should return ok=false")
+	}
+}
+
+func TestPersistentVersionsModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running persistent versions model test with seed: %d", seed)
+
+	current := rbtree.NewPersistent[int, int]()
+	model := make(map[int]int)
+
+	type version struct {
+		tree  *rbtree.PersistentMap[int, int]
+		model map[int]int
+	}
+	versions := make([]version, 0)
+
+	for i := 0; i < 10000; i++ {
+		key := rng.Intn(1000)
+		if rng.Intn(3) == 0 {
+			current = current.Remove(key)
+			delete(model, key)
+		} else {
+			current = current.Insert(key, i)
+			model[key] = i
+		}
+		if i%250 == 0 {
+			versions = append(versions, version{current, maps.Clone(model)})
+		}
+	}
+	versions = append(versions, version{current, model})
+
+	for i, v := range versions {
+		if v.tree.Size() != len(v.model) {
+			t.Fatalf("Version %d: expected size %d, got %d", i, len(v.model), v.tree.Size())
+		}
+		expectedOrder := slices.Sorted(maps.Keys(v.model))
+		actualOrder := make([]int, 0, v.tree.Size())
+		for k, val := range v.tree.InOrder() {
+			if val != v.model[k] {
+				t.Fatalf("Version %d: value mismatch for key %d. Expected %d, got %d", i, k, v.model[k], val)
+			}
+			actualOrder = append(actualOrder, k)
+		}
+		if !slices.Equal(expectedOrder, actualOrder) {
+			t.Fatalf("Version %d: in-order traversal is incorrect", i)
+		}
+	}
+}
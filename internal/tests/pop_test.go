@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPopMinMaxEmpty(t *testing.T) {
+	tree := rbtree.New[int, string]()
+	if _, _, ok := tree.PopMin(); ok {
+		t.Error("PopMin on an empty tree should return ok=false")
+	}
+	if _, _, ok := tree.PopMax(); ok {
+		t.Error("PopMax on an empty tree should return ok=false")
+	}
+}
+
+func TestPopMinMax(t *testing.T) {
+	tree := createTestTree()
+
+	k, v, ok := tree.PopMin()
+	if !ok || k != 10 || v != "v"+string(rune(10)) {
+		t.Errorf("Expected PopMin to return 10, but got k=%v, v=%v, ok=%v", k, v, ok)
+	}
+	k, v, ok = tree.PopMax()
+	if !ok || k != 60 || v != "v"+string(rune(60)) {
+		t.Errorf("Expected PopMax to return 60, but got k=%v, v=%v, ok=%v", k, v, ok)
+	}
+
+	if tree.Size() != 3 {
+		t.Errorf("Expected size 3 after two pops, but got %d", tree.Size())
+	}
+	if tree.ContainsKey(10) || tree.ContainsKey(60) {
+		t.Error("Popped keys should no longer be in the tree")
+	}
+	if actual := collectKeys(tree.InOrder()); !slices.Equal([]int{20, 30, 50}, actual) {
+		t.Errorf("Unexpected content after pops: %v", actual)
+	}
+}
+
+func TestPopMinDrainsInOrder(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running PopMin/PopMax drain test with seed: %d", seed)
+
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(100000)
+		tree.Insert(key, -key)
+	}
+	sorted := collectKeys(tree.InOrder())
+
+	lo, hi := 0, len(sorted)-1
+	for tree.Size() > 0 {
+		var k, v int
+		var expected int
+		if rng.Intn(2) == 0 {
+			k, v, _ = tree.PopMin()
+			expected = sorted[lo]
+			lo++
+		} else {
+			k, v, _ = tree.PopMax()
+			expected = sorted[hi]
+			hi--
+		}
+		if k != expected || v != -expected {
+			t.Fatalf("Expected to pop key %d, but got k=%d, v=%d", expected, k, v)
+		}
+		if tree.Size() != hi-lo+1 {
+			t.Fatalf("Expected size %d, but got %d", hi-lo+1, tree.Size())
+		}
+	}
+}
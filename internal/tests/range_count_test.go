@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"maps"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestCountRange(t *testing.T) {
+	tree := createTestTree()
+
+	tests := []struct {
+		name     string
+		lo, hi   int
+		opts     []rbtree.RangeOption
+		expected int
+	}{
+		{"Half Open", 20, 50, nil, 2},
+		{"Closed", 20, 50, []rbtree.RangeOption{rbtree.IncludeHi}, 3},
+		{"Open", 20, 50, []rbtree.RangeOption{rbtree.ExcludeLo}, 1},
+		{"Whole Map", 0, 100, nil, 5},
+		{"Between Keys", 31, 49, nil, 0},
+		{"Reversed Bounds", 50, 20, nil, 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if count := tree.CountRange(tc.lo, tc.hi, tc.opts...); count != tc.expected {
+				t.Errorf("Expected %d keys, but got %d", tc.expected, count)
+			}
+		})
+	}
+}
+
+func TestSumRange(t *testing.T) {
+	tree := rbtree.NewSumMap[string, float64]()
+	tree.Insert("a", 1.5)
+	tree.Insert("b", 2.5)
+	tree.Insert("c", 4)
+	tree.Insert("d", 8)
+
+	if sum := tree.SumRange("b", "d"); sum != 6.5 {
+		t.Errorf("Expected the sum over [b, d) to be 6.5, but got %v", sum)
+	}
+	tree.Insert("c", 10)
+	tree.Remove("b")
+	if sum := tree.SumRange("a", "d", rbtree.IncludeHi); sum != 19.5 {
+		t.Errorf("Expected the sum over [a, d] to be 19.5 after updates, but got %v", sum)
+	}
+	if sum := tree.SumRange("x", "z"); sum != 0 {
+		t.Errorf("Expected the sum over an empty range to be 0, but got %v", sum)
+	}
+}
+
+func TestRangeCountAndSumModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running CountRange and SumRange model test with seed: %d", seed)
+
+	tree := rbtree.NewSumMap[int, int64]()
+	model := make(map[int]int64)
+	var snapshot *rbtree.AggregateSnapshot[int, int64, int64]
+	var snapshotModel map[int]int64
+
+	for i := 0; i < 10000; i++ {
+		key := rng.Intn(1000)
+		switch rng.Intn(3) {
+		case 0:
+			delete(model, key)
+			tree.Remove(key)
+		default:
+			value := rng.Int63n(1000) - 500
+			model[key] = value
+			tree.Insert(key, value)
+		}
+		// Later writes update the sums of nodes that the snapshot shares
+		// with the map, which must copy them first.
+		if i == 5000 {
+			snapshot = tree.Snapshot()
+			snapshotModel = maps.Clone(model)
+		}
+
+		lo := rng.Intn(1100) - 50
+		hi := lo + rng.Intn(300)
+		expectedCount, expectedSum := 0, int64(0)
+		for k := lo; k <= hi; k++ {
+			if v, ok := model[k]; ok {
+				expectedCount++
+				expectedSum += v
+			}
+		}
+		if count := tree.CountRange(lo, hi, rbtree.IncludeHi); count != expectedCount {
+			t.Fatalf("Step %d: expected %d keys in [%d, %d], but got %d", i, expectedCount, lo, hi, count)
+		}
+		if sum := tree.SumRange(lo, hi, rbtree.IncludeHi); sum != expectedSum {
+			t.Fatalf("Step %d: expected a sum of %d over [%d, %d], but got %d", i, expectedSum, lo, hi, sum)
+		}
+	}
+
+	actual := make(map[int]int64)
+	for k, v := range snapshot.InOrder() {
+		actual[k] = v
+	}
+	if !maps.Equal(snapshotModel, actual) {
+		t.Fatalf("Expected the snapshot to keep %v, but got %v", snapshotModel, actual)
+	}
+	expectedTotal := int64(0)
+	for _, v := range snapshotModel {
+		expectedTotal += v
+	}
+	if sum := snapshot.AggregateAll(); sum != expectedTotal {
+		t.Fatalf("Expected the snapshot to keep a total of %d, but got %d", expectedTotal, sum)
+	}
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func collectKeys[K any, V any](seq iter.Seq2[K, V]) []K {
+	keys := make([]K, 0)
+	for k := range seq {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestRangeBounds(t *testing.T) {
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 100; i += 10 {
+		tree.Insert(i, i)
+	}
+
+	cases := []struct {
+		name     string
+		seq      iter.Seq2[int, int]
+		expected []int
+	}{
+		{"Default Half-Open", tree.Range(20, 50), []int{20, 30, 40}},
+		{"Include Hi", tree.Range(20, 50, rbtree.IncludeHi), []int{20, 30, 40, 50}},
+		{"Exclude Lo", tree.Range(20, 50, rbtree.ExcludeLo), []int{30, 40}},
+		{"Open Interval", tree.Range(20, 50, rbtree.ExcludeLo, rbtree.IncludeHi), []int{30, 40, 50}},
+		{"Bounds Between Keys", tree.Range(15, 45), []int{20, 30, 40}},
+		{"Empty When Lo Equals Hi", tree.Range(30, 30), []int{}},
+		{"Single Key When Both Inclusive", tree.Range(30, 30, rbtree.IncludeHi), []int{30}},
+		{"Empty When Lo Above Hi", tree.Range(60, 20), []int{}},
+		{"Beyond All Keys", tree.Range(200, 300), []int{}},
+		{"RangeFrom", tree.RangeFrom(75), []int{80, 90}},
+		{"RangeFrom Exclusive", tree.RangeFrom(80, rbtree.ExcludeLo), []int{90}},
+		{"RangeTo", tree.RangeTo(20), []int{0, 10}},
+		{"RangeTo Inclusive", tree.RangeTo(20, rbtree.IncludeHi), []int{0, 10, 20}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := collectKeys(tc.seq)
+			if !slices.Equal(tc.expected, actual) {
+				t.Errorf("Expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRangeEarlyStop(t *testing.T) {
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+
+	actual := make([]int, 0)
+	for k := range tree.Range(10, 90) {
+		if k == 13 {
+			break
+		}
+		actual = append(actual, k)
+	}
+	if !slices.Equal([]int{10, 11, 12}, actual) {
+		t.Errorf("Expected iteration to stop at 13, got %v", actual)
+	}
+}
+
+func TestRangeModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running range model test with seed: %d", seed)
+
+	tree := rbtree.New[int, int]()
+	trackingMap := make(map[int]struct{})
+	for i := 0; i < 3000; i++ {
+		key := rng.Intn(5000)
+		tree.Insert(key, key)
+		trackingMap[key] = struct{}{}
+	}
+	sorted := getKeysFromMap(trackingMap)
+	slices.Sort(sorted)
+
+	for i := 0; i < 200; i++ {
+		lo, hi := rng.Intn(5200)-100, rng.Intn(5200)-100
+		expected := make([]int, 0)
+		for _, k := range sorted {
+			if k > lo && k <= hi {
+				expected = append(expected, k)
+			}
+		}
+		actual := collectKeys(tree.Range(lo, hi, rbtree.ExcludeLo, rbtree.IncludeHi))
+		if !slices.Equal(expected, actual) {
+			t.Fatalf("Range(%d, %d] mismatch.\nExpected: %v\nGot:      %v", lo, hi, expected, actual)
+		}
+	}
+}
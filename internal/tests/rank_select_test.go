@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRankAndSelectBasic(t *testing.T) {
+	tree := createTestTree()
+
+	t.Run("Empty Tree", func(t *testing.T) {
+		emptyTree := rbtree.New[int, string]()
+		if r := emptyTree.Rank(10); r != 0 {
+			t.Errorf("Rank on an empty tree should be 0, but got %d", r)
+		}
+		if _, _, ok := emptyTree.Select(0); ok {
+			t.Error("Select on an empty tree should return ok=false")
+		}
+	})
+
+	t.Run("Rank", func(t *testing.T) {
+		cases := map[int]int{5: 0, 10: 0, 15: 1, 30: 2, 35: 3, 60: 4, 100: 5}
+		for key, expected := range cases {
+			if r := tree.Rank(key); r != expected {
+				t.Errorf("Expected Rank(%d) to be %d, but got %d", key, expected, r)
+			}
+		}
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		expected := []int{10, 20, 30, 50, 60}
+		for i, key := range expected {
+			k, v, ok := tree.Select(i)
+			if !ok || k != key || v != "v"+string(rune(key)) {
+				t.Errorf("Expected Select(%d) to find key %d, but got k=%v, v=%v, ok=%v", i, key, k, v, ok)
+			}
+		}
+	})
+
+	t.Run("Select Out Of Range", func(t *testing.T) {
+		if _, _, ok := tree.Select(-1); ok {
+			t.Error("Select(-1) should return ok=false")
+		}
+		if _, _, ok := tree.Select(tree.Size()); ok {
+			t.Error("Select(Size()) should return ok=false")
+		}
+	})
+}
+
+func TestRankAndSelectModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running rank/select model test with seed: %d", seed)
+
+	tree := rbtree.New[int, int]()
+	trackingMap := make(map[int]struct{})
+
+	for i := 0; i < 10000; i++ {
+		key := rng.Intn(2000)
+		if rng.Intn(3) == 0 {
+			tree.Remove(key)
+			delete(trackingMap, key)
+		} else {
+			tree.Insert(key, key*2)
+			trackingMap[key] = struct{}{}
+		}
+
+		if i%500 != 0 {
+			continue
+		}
+		sorted := getKeysFromMap(trackingMap)
+		slices.Sort(sorted)
+		for j, key := range sorted {
+			k, v, ok := tree.Select(j)
+			if !ok || k != key || v != key*2 {
+				t.Fatalf("Step %d: expected Select(%d) to find key %d, but got k=%d, v=%d, ok=%v", i, j, key, k, v, ok)
+			}
+			if r := tree.Rank(key); r != j {
+				t.Fatalf("Step %d: expected Rank(%d) to be %d, but got %d", i, key, j, r)
+			}
+		}
+		probe := rng.Intn(2100) - 50
+		expectedRank, _ := slices.BinarySearch(sorted, probe)
+		if r := tree.Rank(probe); r != expectedRank {
+			t.Fatalf("Step %d: expected Rank(%d) to be %d, but got %d", i, probe, expectedRank, r)
+		}
+	}
+}
@@ -1,8 +1,8 @@
-﻿package tests
+package tests
 
 import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
 	"math/rand"
-	"rb-tree-map/internal/rbtree"
 	"slices"
 	"testing"
 	"time"
@@ -0,0 +1,199 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func buildTree(entries map[int]string) *rbtree.RBTreeMap[int, string] {
+	tree := rbtree.New[int, string]()
+	for k, v := range entries {
+		tree.Insert(k, v)
+	}
+	return tree
+}
+
+func collectEntries(tree *rbtree.RBTreeMap[int, string]) map[int]string {
+	entries := make(map[int]string)
+	for k, v := range tree.InOrder() {
+		entries[k] = v
+	}
+	return entries
+}
+
+func TestSetOperationsBasic(t *testing.T) {
+	left := map[int]string{1: "a1", 2: "a2", 3: "a3", 5: "a5"}
+	right := map[int]string{2: "b2", 3: "b3", 4: "b4", 6: "b6"}
+	concat := func(key int, a, b string) string { return a + "+" + b }
+
+	cases := []struct {
+		name     string
+		method   func(a, b *rbtree.RBTreeMap[int, string]) *rbtree.RBTreeMap[int, string]
+		into     func(dst, src *rbtree.RBTreeMap[int, string])
+		expected map[int]string
+	}{
+		{
+			"Union",
+			func(a, b *rbtree.RBTreeMap[int, string]) *rbtree.RBTreeMap[int, string] { return a.Union(b, concat) },
+			func(dst, src *rbtree.RBTreeMap[int, string]) { rbtree.UnionInto(dst, src, concat) },
+			map[int]string{1: "a1", 2: "a2+b2", 3: "a3+b3", 4: "b4", 5: "a5", 6: "b6"},
+		},
+		{
+			"Intersection",
+			func(a, b *rbtree.RBTreeMap[int, string]) *rbtree.RBTreeMap[int, string] {
+				return a.Intersection(b, concat)
+			},
+			func(dst, src *rbtree.RBTreeMap[int, string]) { rbtree.IntersectionInto(dst, src, concat) },
+			map[int]string{2: "a2+b2", 3: "a3+b3"},
+		},
+		{
+			"Difference",
+			(*rbtree.RBTreeMap[int, string]).Difference,
+			rbtree.DifferenceInto[int, string],
+			map[int]string{1: "a1", 5: "a5"},
+		},
+		{
+			"SymmetricDifference",
+			(*rbtree.RBTreeMap[int, string]).SymmetricDifference,
+			rbtree.SymmetricDifferenceInto[int, string],
+			map[int]string{1: "a1", 4: "b4", 5: "a5", 6: "b6"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, b := buildTree(left), buildTree(right)
+			result := c.method(a, b)
+			if actual := collectEntries(result); !maps.Equal(c.expected, actual) {
+				t.Errorf("Expected %v, got %v", c.expected, actual)
+			}
+			if !maps.Equal(left, collectEntries(a)) || !maps.Equal(right, collectEntries(b)) {
+				t.Errorf("Expected both arguments to be unchanged, but got %v and %v", collectEntries(a), collectEntries(b))
+			}
+
+			c.into(a, b)
+			if actual := collectEntries(a); !maps.Equal(c.expected, actual) {
+				t.Errorf("Into: expected %v, got %v", c.expected, actual)
+			}
+			if b.Size() != 0 {
+				t.Errorf("Into: expected src to be empty, but got size %d", b.Size())
+			}
+		})
+	}
+
+	t.Run("Arguments Stay Usable", func(t *testing.T) {
+		a, b := buildTree(left), buildTree(right)
+		it := a.Cursor()
+		it.First()
+		result := a.Union(b, concat)
+		it.Next()
+		if it.Key() != 2 {
+			t.Errorf("Expected a cursor over an argument to keep working, but it is at %d", it.Key())
+		}
+		result.Insert(100, "r")
+		a.Insert(200, "a")
+		b.Remove(4)
+		if a.ContainsKey(100) || result.ContainsKey(200) || !result.ContainsKey(4) {
+			t.Error("Expected the result and the arguments to be independent")
+		}
+	})
+
+	t.Run("Empty Arguments", func(t *testing.T) {
+		empty := map[int]string{}
+		if r := buildTree(empty).Union(buildTree(right), concat); r.Size() != len(right) {
+			t.Errorf("Union with an empty map should have size %d, got %d", len(right), r.Size())
+		}
+		if r := buildTree(left).Intersection(buildTree(empty), concat); r.Size() != 0 {
+			t.Errorf("Intersection with an empty map should be empty, got size %d", r.Size())
+		}
+		if r := buildTree(left).Difference(buildTree(empty)); r.Size() != len(left) {
+			t.Errorf("Difference with an empty map should have size %d, got %d", len(left), r.Size())
+		}
+		dst := buildTree(empty)
+		rbtree.UnionInto(dst, buildTree(right), concat)
+		if dst.Size() != len(right) {
+			t.Errorf("UnionInto an empty map should have size %d, got %d", len(right), dst.Size())
+		}
+	})
+}
+
+func TestSetOperationsModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running set operations model test with seed: %d", seed)
+
+	randomEntries := func(n int, tag string) map[int]string {
+		entries := make(map[int]string)
+		for i := 0; i < n; i++ {
+			key := rng.Intn(3000)
+			entries[key] = tag
+		}
+		return entries
+	}
+	pickFirst := func(key int, a, b string) string { return a }
+
+	for round := 0; round < 30; round++ {
+		a := randomEntries(rng.Intn(2000), "a")
+		b := randomEntries(rng.Intn(50), "b")
+		if rng.Intn(2) == 0 {
+			a, b = b, a
+		}
+
+		union := maps.Clone(b)
+		maps.Copy(union, a)
+		intersection := make(map[int]string)
+		difference := make(map[int]string)
+		symmetric := make(map[int]string)
+		for k, v := range a {
+			if _, ok := b[k]; ok {
+				intersection[k] = v
+			} else {
+				difference[k] = v
+				symmetric[k] = v
+			}
+		}
+		for k, v := range b {
+			if _, ok := a[k]; !ok {
+				symmetric[k] = v
+			}
+		}
+
+		into := func(op func(dst, src *rbtree.RBTreeMap[int, string])) *rbtree.RBTreeMap[int, string] {
+			dst := buildTree(a)
+			op(dst, buildTree(b))
+			return dst
+		}
+		treeA, treeB := buildTree(a), buildTree(b)
+		checks := []struct {
+			name     string
+			result   *rbtree.RBTreeMap[int, string]
+			expected map[int]string
+		}{
+			{"Union", treeA.Union(treeB, pickFirst), union},
+			{"Intersection", treeA.Intersection(treeB, pickFirst), intersection},
+			{"Difference", treeA.Difference(treeB), difference},
+			{"SymmetricDifference", treeA.SymmetricDifference(treeB), symmetric},
+			{"UnionInto", into(func(dst, src *rbtree.RBTreeMap[int, string]) { rbtree.UnionInto(dst, src, pickFirst) }), union},
+			{"IntersectionInto", into(func(dst, src *rbtree.RBTreeMap[int, string]) { rbtree.IntersectionInto(dst, src, pickFirst) }), intersection},
+			{"DifferenceInto", into(rbtree.DifferenceInto[int, string]), difference},
+			{"SymmetricDifferenceInto", into(rbtree.SymmetricDifferenceInto[int, string]), symmetric},
+		}
+		if !maps.Equal(a, collectEntries(treeA)) || !maps.Equal(b, collectEntries(treeB)) {
+			t.Fatalf("Round %d: the set operations changed their arguments", round)
+		}
+		for _, c := range checks {
+			if c.result.Size() != len(c.expected) {
+				t.Fatalf("Round %d %s: expected size %d, got %d", round, c.name, len(c.expected), c.result.Size())
+			}
+			if actual := collectKeys(c.result.InOrder()); !slices.Equal(slices.Sorted(maps.Keys(c.expected)), actual) {
+				t.Fatalf("Round %d %s: keys are incorrect", round, c.name)
+			}
+			if !maps.Equal(c.expected, collectEntries(c.result)) {
+				t.Fatalf("Round %d %s: values are incorrect", round, c.name)
+			}
+		}
+	}
+}
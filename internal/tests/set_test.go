@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSetOf(keys ...int) *rbtree.RBTreeSet[int] {
+	s := rbtree.NewSet[int]()
+	for _, k := range keys {
+		s.Add(k)
+	}
+	return s
+}
+
+func TestSetBasic(t *testing.T) {
+	s := newSetOf(30, 10, 20)
+
+	t.Run("Add Delete Has", func(t *testing.T) {
+		if !s.Add(40) {
+			t.Error("Expected Add(40) to report a new key")
+		}
+		if s.Add(10) {
+			t.Error("Expected Add(10) to report an existing key")
+		}
+		if !s.Has(20) || s.Has(25) {
+			t.Error("Expected Has to find 20 and not 25")
+		}
+		if !s.Delete(40) || s.Delete(40) {
+			t.Error("Expected Delete(40) to succeed once")
+		}
+		if s.Size() != 3 {
+			t.Errorf("Expected size 3, but got %d", s.Size())
+		}
+	})
+
+	t.Run("Iteration", func(t *testing.T) {
+		if actual := slices.Collect(s.All()); !slices.Equal([]int{10, 20, 30}, actual) {
+			t.Errorf("Expected [10 20 30], but got %v", actual)
+		}
+		if actual := slices.Collect(s.Backward()); !slices.Equal([]int{30, 20, 10}, actual) {
+			t.Errorf("Expected [30 20 10], but got %v", actual)
+		}
+	})
+
+	t.Run("Navigation", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			query    func(int) (int, bool)
+			key      int
+			expected int
+			found    bool
+		}{
+			{"Ceiling Equal", s.Ceiling, 20, 20, true},
+			{"Ceiling Between", s.Ceiling, 21, 30, true},
+			{"Higher", s.Higher, 20, 30, true},
+			{"Higher Past End", s.Higher, 30, 0, false},
+			{"Floor Equal", s.Floor, 20, 20, true},
+			{"Floor Between", s.Floor, 19, 10, true},
+			{"Lower", s.Lower, 20, 10, true},
+			{"Lower Before Start", s.Lower, 10, 0, false},
+		}
+		for _, tc := range tests {
+			k, ok := tc.query(tc.key)
+			if ok != tc.found || (ok && k != tc.expected) {
+				t.Errorf("%s(%d): expected %d, %v, but got %d, %v", tc.name, tc.key, tc.expected, tc.found, k, ok)
+			}
+		}
+		if k, ok := s.Min(); !ok || k != 10 {
+			t.Errorf("Expected Min to be 10, but got %d, %v", k, ok)
+		}
+		if k, ok := s.Max(); !ok || k != 30 {
+			t.Errorf("Expected Max to be 30, but got %d, %v", k, ok)
+		}
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		if !s.Equal(newSetOf(10, 20, 30)) {
+			t.Error("Expected sets with the same keys to be equal")
+		}
+		if s.Equal(newSetOf(10, 20, 31)) || s.Equal(newSetOf(10, 20)) {
+			t.Error("Expected sets with different keys to differ")
+		}
+		folded := rbtree.NewSetWithComparator[string](func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+		folded.Add("Go")
+		other := rbtree.NewSetWithComparator[string](strings.Compare)
+		other.Add("GO")
+		if !folded.Equal(other) {
+			t.Error("Expected Equal to use the receiver's comparator")
+		}
+	})
+}
+
+func TestSetAlgebra(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b *rbtree.RBTreeSet[int]) *rbtree.RBTreeSet[int]
+		expected []int
+	}{
+		{"Union", (*rbtree.RBTreeSet[int]).Union, []int{1, 2, 3, 4, 5}},
+		{"Intersection", (*rbtree.RBTreeSet[int]).Intersection, []int{3}},
+		{"Difference", (*rbtree.RBTreeSet[int]).Difference, []int{1, 2}},
+		{"SymmetricDifference", (*rbtree.RBTreeSet[int]).SymmetricDifference, []int{1, 2, 4, 5}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := newSetOf(1, 2, 3), newSetOf(3, 4, 5)
+			var result *rbtree.RBTreeSet[int]
+			// The operands may be in the middle of an iteration.
+			for range a.All() {
+				for range b.All() {
+					result = tc.op(a, b)
+				}
+			}
+			if actual := slices.Collect(result.All()); !slices.Equal(tc.expected, actual) {
+				t.Errorf("Expected %v, but got %v", tc.expected, actual)
+			}
+			if !a.Equal(newSetOf(1, 2, 3)) || !b.Equal(newSetOf(3, 4, 5)) {
+				t.Errorf("Expected both operands to be unchanged, but got %v and %v", slices.Collect(a.All()), slices.Collect(b.All()))
+			}
+		})
+	}
+}
+
+func TestSetModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running set model test with seed: %d", seed)
+
+	s := rbtree.NewSet[int]()
+	model := make(map[int]bool)
+	for i := 0; i < 20000; i++ {
+		key := rng.Intn(2000)
+		if rng.Intn(3) == 0 {
+			if s.Delete(key) != model[key] {
+				t.Fatalf("Step %d: Delete(%d) disagreed with the model", i, key)
+			}
+			delete(model, key)
+		} else {
+			if s.Add(key) == model[key] {
+				t.Fatalf("Step %d: Add(%d) disagreed with the model", i, key)
+			}
+			model[key] = true
+		}
+	}
+
+	if actual := slices.Collect(s.All()); !slices.Equal(slices.Sorted(maps.Keys(model)), actual) {
+		t.Fatal("Keys after mixed operations are incorrect")
+	}
+	for i := 0; i < 1000; i++ {
+		key := rng.Intn(2200) - 100
+		if s.Has(key) != model[key] {
+			t.Fatalf("Expected Has(%d) to be %v", key, model[key])
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"maps"
+	"math/rand"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSnapshotIsFrozen(t *testing.T) {
+	tree := createTestTree()
+	snap := tree.Snapshot()
+
+	tree.Insert(40, "new")
+	tree.Insert(30, "changed")
+	tree.Remove(10)
+
+	if snap.Size() != 5 {
+		t.Errorf("Expected snapshot size 5, but got %d", snap.Size())
+	}
+	if snap.ContainsKey(40) {
+		t.Error("Snapshot should not see keys inserted after it was taken")
+	}
+	if !snap.ContainsKey(10) {
+		t.Error("Snapshot should still see keys removed after it was taken")
+	}
+	if v, _ := snap.Get(30); v != "v"+string(rune(30)) {
+		t.Errorf("Snapshot should keep the old value for key 30, but got %q", v)
+	}
+	if k, _, ok := snap.LowerBound(35); !ok || k != 50 {
+		t.Errorf("Expected snapshot LowerBound(35) to find 50, but got k=%v, ok=%v", k, ok)
+	}
+	if k, _, ok := snap.UpperBound(5); !ok || k != 10 {
+		t.Errorf("Expected snapshot UpperBound(5) to find 10, but got k=%v, ok=%v", k, ok)
+	}
+	if actual := collectKeys(snap.InOrder()); !slices.Equal([]int{10, 20, 30, 50, 60}, actual) {
+		t.Errorf("Unexpected snapshot content: %v", actual)
+	}
+
+	if v, _ := tree.Get(30); v != "changed" {
+		t.Errorf("Map should see its own update for key 30, but got %q", v)
+	}
+	if actual := collectKeys(tree.InOrder()); !slices.Equal([]int{20, 30, 40, 50, 60}, actual) {
+		t.Errorf("Unexpected map content: %v", actual)
+	}
+}
+
+func TestSnapshotCursorAfterSnapshot(t *testing.T) {
+	tree := createTestTree()
+	c := tree.Cursor()
+	c.Seek(20)
+
+	snap := tree.Snapshot()
+	c.SetValue("cursor")
+	tree.Insert(50, "insert")
+
+	if !c.Next() || c.Key() != 30 || !c.Next() || c.Value() != "insert" {
+		t.Errorf("Cursor should see the map's current values, but got key %d, value %q", c.Key(), c.Value())
+	}
+	c.Prev()
+	c.Prev()
+	if c.Value() != "cursor" {
+		t.Errorf("Expected the cursor to see its own update, but got %q", c.Value())
+	}
+	if v, _ := snap.Get(20); v != "v"+string(rune(20)) {
+		t.Errorf("Snapshot should not see the cursor update, but got %q", v)
+	}
+}
+
+func TestSnapshotModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running snapshot model test with seed: %d", seed)
+
+	tree := rbtree.New[int, int]()
+	model := make(map[int]int)
+
+	type frozen struct {
+		snap  *rbtree.Snapshot[int, int]
+		model map[int]int
+	}
+	snapshots := make([]frozen, 0)
+
+	for i := 0; i < 20000; i++ {
+		key := rng.Intn(1000)
+		switch op := rng.Intn(100); {
+		case op < 50:
+			tree.Insert(key, i)
+			model[key] = i
+		case op < 99:
+			tree.Remove(key)
+			delete(model, key)
+		default:
+			snapshots = append(snapshots, frozen{tree.Snapshot(), maps.Clone(model)})
+		}
+	}
+	snapshots = append(snapshots, frozen{tree.Snapshot(), model})
+
+	for i, f := range snapshots {
+		if f.snap.Size() != len(f.model) {
+			t.Fatalf("Snapshot %d: expected size %d, got %d", i, len(f.model), f.snap.Size())
+		}
+		expectedOrder := slices.Sorted(maps.Keys(f.model))
+		actualOrder := make([]int, 0, f.snap.Size())
+		for k, v := range f.snap.InOrder() {
+			if v != f.model[k] {
+				t.Fatalf("Snapshot %d: value mismatch for key %d. Expected %d, got %d", i, k, f.model[k], v)
+			}
+			actualOrder = append(actualOrder, k)
+		}
+		if !slices.Equal(expectedOrder, actualOrder) {
+			t.Fatalf("Snapshot %d: in-order traversal is incorrect", i)
+		}
+	}
+}
+
+func TestSnapshotConcurrentReaders(t *testing.T) {
+	tree := rbtree.New[int, int]()
+	for i := 0; i < 1000; i++ {
+		tree.Insert(i, i)
+	}
+
+	var wg sync.WaitGroup
+	for reader := 0; reader < 4; reader++ {
+		snap := tree.Snapshot()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for round := 0; round < 20; round++ {
+				count := 0
+				for k, v := range snap.InOrder() {
+					if k != v {
+						t.Errorf("Snapshot returned mismatched entry %d=%d", k, v)
+						return
+					}
+					count++
+				}
+				if count != 1000 {
+					t.Errorf("Snapshot should always contain 1000 entries, but got %d", count)
+					return
+				}
+			}
+		}()
+
+		for i := 0; i < 2000; i++ {
+			tree.Remove(i % 1000)
+			tree.Insert(i%1000, i%1000)
+		}
+	}
+	wg.Wait()
+}
+
+func heapInUse() uint64 {
+	runtime.GC()
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+func TestSnapshotDoesNotPinChurn(t *testing.T) {
+	const keys = 1000
+	tree := rbtree.New[int, int]()
+	for i := 0; i < keys; i++ {
+		tree.Insert(i, i)
+	}
+	snap := tree.Snapshot()
+
+	churn := func(rounds int) {
+		for round := 0; round < rounds; round++ {
+			for i := 0; i < keys; i++ {
+				tree.Remove(i)
+				tree.Insert(i, round)
+			}
+		}
+	}
+
+	churn(200)
+	before := heapInUse()
+	churn(600)
+	after := heapInUse()
+
+	// Without a snapshot the heap stays flat. A held snapshot may keep its
+	// own nodes and one copy of each, but not grow with the writes.
+	if after > before && after-before > 512<<10 {
+		t.Errorf("Expected the heap to stay flat while a snapshot is held, but it grew by %d bytes", after-before)
+	}
+	if snap.Size() != keys {
+		t.Errorf("Expected the snapshot to keep %d entries, but got %d", keys, snap.Size())
+	}
+	runtime.KeepAlive(snap)
+}
@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSplitBasic(t *testing.T) {
+	tree := createTestTree()
+	left, right := tree.Split(30)
+
+	if actual := collectKeys(left.InOrder()); !slices.Equal([]int{10, 20}, actual) {
+		t.Errorf("Unexpected left content: %v", actual)
+	}
+	if actual := collectKeys(right.InOrder()); !slices.Equal([]int{30, 50, 60}, actual) {
+		t.Errorf("Unexpected right content: %v", actual)
+	}
+	if left.Size() != 2 || right.Size() != 3 {
+		t.Errorf("Expected sizes 2 and 3, but got %d and %d", left.Size(), right.Size())
+	}
+	if tree.Size() != 0 || tree.ContainsKey(30) {
+		t.Error("The split map should be empty")
+	}
+
+	if v, ok := right.Get(50); !ok || v != "v"+string(rune(50)) {
+		t.Errorf("Expected right to keep the value of key 50, but got v=%q, ok=%v", v, ok)
+	}
+	if r := right.Rank(60); r != 2 {
+		t.Errorf("Expected Rank(60) in right to be 2, but got %d", r)
+	}
+}
+
+func TestSplitAtExtremes(t *testing.T) {
+	tree := createTestTree()
+	left, right := tree.Split(0)
+	if left.Size() != 0 || right.Size() != 5 {
+		t.Errorf("Split below the minimum should put everything right, got sizes %d and %d", left.Size(), right.Size())
+	}
+
+	left, right = right.Split(100)
+	if left.Size() != 5 || right.Size() != 0 {
+		t.Errorf("Split above the maximum should put everything left, got sizes %d and %d", left.Size(), right.Size())
+	}
+
+	empty := rbtree.New[int, string]()
+	left, right = empty.Split(10)
+	if left.Size() != 0 || right.Size() != 0 {
+		t.Error("Splitting an empty map should produce two empty maps")
+	}
+}
+
+func TestJoinBasic(t *testing.T) {
+	left := rbtree.New[int, int]()
+	right := rbtree.New[int, int]()
+	for i := 0; i < 10; i++ {
+		left.Insert(i, i)
+	}
+	for i := 100; i < 300; i++ {
+		right.Insert(i, i)
+	}
+
+	joined := rbtree.Join(left, right)
+	if joined.Size() != 210 {
+		t.Errorf("Expected joined size 210, but got %d", joined.Size())
+	}
+	if left.Size() != 0 || right.Size() != 0 {
+		t.Error("Join should leave both arguments empty")
+	}
+
+	expected := make([]int, 0, 210)
+	for i := 0; i < 10; i++ {
+		expected = append(expected, i)
+	}
+	for i := 100; i < 300; i++ {
+		expected = append(expected, i)
+	}
+	if actual := collectKeys(joined.InOrder()); !slices.Equal(expected, actual) {
+		t.Error("Joined map content is incorrect")
+	}
+
+	joined.Insert(50, 50)
+	joined.Remove(100)
+	if !joined.ContainsKey(50) || joined.ContainsKey(100) || joined.Size() != 210 {
+		t.Error("Joined map should remain usable after Join")
+	}
+}
+
+func TestJoinWithEmptyMaps(t *testing.T) {
+	tree := createTestTree()
+	joined := rbtree.Join(rbtree.New[int, string](), tree)
+	if joined.Size() != 5 {
+		t.Errorf("Expected size 5 after joining with an empty left map, but got %d", joined.Size())
+	}
+	joined = rbtree.Join(joined, rbtree.New[int, string]())
+	if actual := collectKeys(joined.InOrder()); !slices.Equal([]int{10, 20, 30, 50, 60}, actual) {
+		t.Errorf("Unexpected content after joining with an empty right map: %v", actual)
+	}
+}
+
+func TestJoinPanicsOnOverlappingKeys(t *testing.T) {
+	left := createTestTree()
+	right := rbtree.New[int, string]()
+	right.Insert(60, "overlap")
+
+	expectPanic(t, "Join with overlapping keys", func() {
+		rbtree.Join(left, right)
+	})
+	if left.Size() != 5 || right.Size() != 1 {
+		t.Error("A failed Join should leave both maps unchanged")
+	}
+}
+
+func TestSplitJoinModel(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running split/join model test with seed: %d", seed)
+
+	tree := rbtree.New[int, int]()
+	trackingMap := make(map[int]struct{})
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(20000)
+		tree.Insert(key, key)
+		trackingMap[key] = struct{}{}
+	}
+	expected := getKeysFromMap(trackingMap)
+	slices.Sort(expected)
+
+	for round := 0; round < 50; round++ {
+		key := rng.Intn(21000) - 500
+		left, right := tree.Split(key)
+
+		pos, _ := slices.BinarySearch(expected, key)
+		if left.Size() != pos || right.Size() != len(expected)-pos {
+			t.Fatalf("Split(%d): expected sizes %d and %d, got %d and %d", key, pos, len(expected)-pos, left.Size(), right.Size())
+		}
+		if k, _, ok := left.Max(); ok && k >= key {
+			t.Fatalf("Split(%d): left contains key %d", key, k)
+		}
+		if k, _, ok := right.Min(); ok && k < key {
+			t.Fatalf("Split(%d): right contains key %d", key, k)
+		}
+
+		tree = rbtree.Join(left, right)
+		if actual := collectKeys(tree.InOrder()); !slices.Equal(expected, actual) {
+			t.Fatalf("Round %d: content changed after Split and Join", round)
+		}
+	}
+
+	for i := 0; i < 2000; i++ {
+		key := rng.Intn(20000)
+		tree.Remove(key)
+		delete(trackingMap, key)
+	}
+	expected = getKeysFromMap(trackingMap)
+	slices.Sort(expected)
+	if actual := collectKeys(tree.InOrder()); !slices.Equal(expected, actual) {
+		t.Fatal("Joined map content is incorrect after further removals")
+	}
+}
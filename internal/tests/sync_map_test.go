@@ -0,0 +1,381 @@
+package tests
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSyncMapBasic(t *testing.T) {
+	m := rbtree.NewSyncMap[int, string]()
+	for _, k := range []int{30, 20, 50, 10, 60} {
+		m.Insert(k, "v"+string(rune(k)))
+	}
+
+	if m.Size() != 5 {
+		t.Errorf("Expected size 5, but got %d", m.Size())
+	}
+	if v, ok := m.Get(20); !ok || v != "v"+string(rune(20)) {
+		t.Errorf("Expected Get(20) to find its value, but got v=%q, ok=%v", v, ok)
+	}
+	if k, _, ok := m.Floor(35); !ok || k != 30 {
+		t.Errorf("Expected Floor(35) to find 30, but got k=%v, ok=%v", k, ok)
+	}
+	if r := m.Rank(50); r != 3 {
+		t.Errorf("Expected Rank(50) to be 3, but got %d", r)
+	}
+	if actual := collectKeys(m.Range(20, 60)); !slices.Equal([]int{20, 30, 50}, actual) {
+		t.Errorf("Unexpected Range(20, 60) content: %v", actual)
+	}
+	if actual := collectKeys(m.Backward()); !slices.Equal([]int{60, 50, 30, 20, 10}, actual) {
+		t.Errorf("Unexpected Backward content: %v", actual)
+	}
+	if k, _, ok := m.PopMin(); !ok || k != 10 {
+		t.Errorf("Expected PopMin to return 10, but got k=%v, ok=%v", k, ok)
+	}
+	m.Remove(60)
+	if m.ContainsKey(60) || m.Size() != 3 {
+		t.Errorf("Expected key 60 to be removed and size 3, but size is %d", m.Size())
+	}
+}
+
+func TestSyncMapConcurrentWriters(t *testing.T) {
+	const writers = 8
+	const keysPerWriter = 2000
+
+	m := rbtree.NewSyncMap[int, int]()
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(w)))
+			base := w * keysPerWriter
+			for i := 0; i < keysPerWriter; i++ {
+				m.Insert(base+i, w)
+			}
+			for i := 0; i < keysPerWriter; i++ {
+				if rng.Intn(2) == 0 {
+					m.Remove(base + i)
+				}
+			}
+			for i := 0; i < keysPerWriter; i += 2 {
+				m.Insert(base+i, -w)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	prev := -1
+	count := 0
+	for k, v := range m.InOrder() {
+		if k <= prev {
+			t.Fatalf("Keys are not in ascending order: %d came after %d", k, prev)
+		}
+		w := k / keysPerWriter
+		if k%2 == 0 && v != -w {
+			t.Fatalf("Expected even key %d to have value %d, but got %d", k, -w, v)
+		}
+		prev = k
+		count++
+	}
+	if count != m.Size() {
+		t.Errorf("Iteration yielded %d entries, but Size is %d", count, m.Size())
+	}
+}
+
+func TestSyncMapStressReadersAndWriters(t *testing.T) {
+	const keySpace = 1000
+
+	m := rbtree.NewSyncMap[int, int]()
+	for i := 0; i < keySpace; i += 2 {
+		m.Insert(i, i)
+	}
+
+	var writers, readers sync.WaitGroup
+	stop := make(chan struct{})
+
+	for w := 0; w < 4; w++ {
+		writers.Add(1)
+		go func(seed int64) {
+			defer writers.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for i := 0; i < 5000; i++ {
+				key := rng.Intn(keySpace)
+				switch rng.Intn(4) {
+				case 0, 1:
+					m.Insert(key, key)
+				case 2:
+					m.Remove(key)
+				case 3:
+					if k, v, ok := m.PopMax(); ok {
+						m.Insert(k, v)
+					}
+				}
+			}
+		}(int64(w))
+	}
+
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func(seed int64) {
+			defer readers.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				key := rng.Intn(keySpace)
+				if v, ok := m.Get(key); ok && v != key {
+					t.Errorf("Get(%d) returned mismatched value %d", key, v)
+					return
+				}
+				m.Rank(key)
+				m.LowerBound(key)
+				prev := -1
+				for k, v := range m.Range(key, key+50) {
+					if k <= prev || k != v {
+						t.Errorf("Range returned an invalid entry %d=%d after %d", k, v, prev)
+						return
+					}
+					prev = k
+				}
+				snap := m.Snapshot()
+				count := 0
+				for range snap.InOrder() {
+					count++
+				}
+				if count != snap.Size() {
+					t.Errorf("Snapshot iteration yielded %d entries, but Size is %d", count, snap.Size())
+					return
+				}
+			}
+		}(int64(100 + r))
+	}
+
+	writers.Wait()
+	close(stop)
+	readers.Wait()
+}
+
+func TestSyncMapIteratorHoldsReadLock(t *testing.T) {
+	m := rbtree.NewSyncMap[int, int]()
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i)
+	}
+
+	inserted := make(chan struct{})
+	for k := range m.InOrder() {
+		if k != 0 {
+			continue
+		}
+		go func() {
+			m.Insert(100, 100)
+			close(inserted)
+		}()
+		select {
+		case <-inserted:
+			t.Fatalf("Expected the writer to wait until the loop finishes, but it got the lock")
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+
+	select {
+	case <-inserted:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the writer to get the lock after the loop, but it is still waiting")
+	}
+	if !m.ContainsKey(100) {
+		t.Errorf("Expected key 100 to be inserted after the loop")
+	}
+}
+
+func TestSyncMapSnapshotLoopCallsBack(t *testing.T) {
+	m := rbtree.NewSyncMap[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Insert(i, i)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1000; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				m.Insert(i, i)
+			}
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for k, v := range m.Snapshot().InOrder() {
+			if got, ok := m.Get(k); !ok || got != v {
+				t.Errorf("Expected Get(%d) to return %d, but got %d, ok=%v", k, v, got, ok)
+			}
+			m.Insert(k, v*2)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected a loop over a snapshot to call back into the map, but it blocked")
+	}
+	close(stop)
+	wg.Wait()
+
+	for i := 0; i < 100; i++ {
+		if v, _ := m.Get(i); v != i*2 {
+			t.Errorf("Expected key %d to hold %d, but got %d", i, i*2, v)
+		}
+	}
+}
+
+func TestSyncMapZeroValue(t *testing.T) {
+	var m rbtree.SyncMap[int, string]
+	if m.Size() != 0 || m.ContainsKey(1) {
+		t.Fatalf("Expected the zero SyncMap to be empty, but Size is %d", m.Size())
+	}
+	m.Insert(2, "b")
+	m.Insert(1, "a")
+	if v, ok := m.Get(1); !ok || v != "a" {
+		t.Errorf("Expected Get(1) to return \"a\", but got %q, ok=%v", v, ok)
+	}
+	if actual := collectKeys(m.InOrder()); !slices.Equal([]int{1, 2}, actual) {
+		t.Errorf("Unexpected InOrder content: %v", actual)
+	}
+
+	var unordered rbtree.SyncMap[struct{ A int }, int]
+	if err := unordered.UnmarshalJSON([]byte("[]")); err == nil {
+		t.Errorf("Expected decoding into a zero SyncMap without a key order to fail")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected Insert on a zero SyncMap without a key order to panic")
+			}
+		}()
+		unordered.Insert(struct{ A int }{1}, 1)
+	}()
+}
+
+func TestSyncMapZeroValueDecodeWhileReading(t *testing.T) {
+	src := rbtree.NewSyncMap[int, int]()
+	for i := 0; i < 100; i++ {
+		src.Insert(i, i)
+	}
+	data, err := src.GobEncode()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	var m rbtree.SyncMap[int, int]
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := m.GobDecode(data); err != nil {
+			t.Errorf("Expected no error, but got %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		prev := -1
+		for k := range m.InOrder() {
+			if k <= prev {
+				t.Errorf("Keys are not in ascending order: %d came after %d", k, prev)
+			}
+			prev = k
+		}
+	}()
+	wg.Wait()
+
+	if m.Size() != 100 {
+		t.Errorf("Expected 100 entries after decoding, but got %d", m.Size())
+	}
+}
+
+func TestSyncMapSplit(t *testing.T) {
+	m := rbtree.NewSyncMap[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Insert(i, i)
+	}
+
+	left, right := m.Split(40)
+	if m.Size() != 0 {
+		t.Errorf("Expected Split to leave the map empty, but Size is %d", m.Size())
+	}
+	if left.Size() != 40 || right.Size() != 60 {
+		t.Fatalf("Expected sizes 40 and 60, but got %d and %d", left.Size(), right.Size())
+	}
+	if k, _, _ := left.Max(); k != 39 {
+		t.Errorf("Expected the left map to end at 39, but it ends at %d", k)
+	}
+	if k, _, _ := right.Min(); k != 40 {
+		t.Errorf("Expected the right map to start at 40, but it starts at %d", k)
+	}
+	right.Insert(1000, 1000)
+	if left.ContainsKey(1000) {
+		t.Errorf("Expected the two halves to be independent")
+	}
+}
+
+func TestSyncMapCursor(t *testing.T) {
+	m := rbtree.NewSyncMap[int, int]()
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i)
+	}
+
+	c := m.Cursor()
+	var keys []int
+	for ok := c.First(); ok; ok = c.Next() {
+		keys = append(keys, c.Key())
+		switch c.Key() {
+		case 2:
+			// Another writer removes the entry the cursor is on.
+			m.Remove(2)
+		case 4:
+			m.Remove(5)
+			m.Insert(6, 60)
+		case 7:
+			c.SetValue(70)
+		}
+	}
+	if !slices.Equal([]int{0, 1, 2, 3, 4, 6, 7, 8, 9}, keys) {
+		t.Errorf("Unexpected cursor keys: %v", keys)
+	}
+	if v, _ := m.Get(7); v != 70 {
+		t.Errorf("Expected SetValue to store 70, but got %d", v)
+	}
+
+	if !c.Seek(8) || c.Key() != 8 {
+		t.Fatalf("Expected Seek(8) to find 8")
+	}
+	m.Remove(7)
+	if !c.Prev() || c.Key() != 6 || c.Value() != 60 {
+		t.Errorf("Expected Prev to skip the removed 7 and reach 6=60, but got %d=%d", c.Key(), c.Value())
+	}
+	c.Delete()
+	if !c.Valid() || c.Key() != 8 || m.ContainsKey(6) {
+		t.Errorf("Expected Delete to remove 6 and move to 8, but the cursor is at %d", c.Key())
+	}
+
+	m.Remove(8)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected SetValue on a removed entry to panic")
+		}
+	}()
+	c.SetValue(0)
+}
@@ -0,0 +1,724 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+type color bool
+
+const (
+	BLACK color = false
+	RED   color = true
+)
+
+func compareFromLess[K any](less func(a, b K) bool) func(a, b K) int {
+	return func(a, b K) int {
+		if less(a, b) {
+			return -1
+		}
+		if less(b, a) {
+			return 1
+		}
+		return 0
+	}
+}
+
+type Node[K any, V any] struct {
+	key   K
+	value V
+	color color
+	// replaced is set once the node has been copied out of the live tree.
+	// Iterators and cursors holding it then find the copy by key.
+	replaced bool
+	size     int
+	parent   *Node[K, V]
+	left     *Node[K, V]
+	right    *Node[K, V]
+	// gen is the generation of the map that owns the node. Nodes from any
+	// other generation may be shared with a Snapshot or another map and are
+	// copied before they are changed.
+	gen uint64
+}
+
+type RBTreeMap[K any, V any] struct {
+	root     *Node[K, V]
+	sentinel *Node[K, V]
+	size     int
+	mod      int
+	gen      uint64
+	frozen   bool
+	compare  func(a, b K) int
+	// naturalOrder is set when compare is cmp.Compare, so the map can be
+	// decoded without the caller supplying a comparator.
+	naturalOrder bool
+	// augment, when set, recomputes the data a node keeps about its subtree
+	// from the node and its children. It is called bottom-up on every node
+	// whose subtree or value changes.
+	augment func(node *Node[K, V])
+	// rotated, when set, is called after every rotation with the node that
+	// moved up and its child that moved down, once both are augmented.
+	rotated func(parent, child *Node[K, V])
+
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+}
+
+// New creates a map ordered by cmp.Compare, so a NaN key sorts before any
+// other float and is equal to itself, and -0.0 and +0.0 are the same key.
+func New[K cmp.Ordered, V any]() *RBTreeMap[K, V] {
+	r := NewWithComparator[K, V](cmp.Compare[K])
+	r.naturalOrder = true
+	return r
+}
+
+// NewWithCompare creates a map ordered by a strict weak ordering. Two keys
+// are treated as the same key when neither is less than the other.
+func NewWithCompare[K any, V any](less func(a, b K) bool) *RBTreeMap[K, V] {
+	return NewWithComparator[K, V](compareFromLess(less))
+}
+
+var (
+	sentinels   sync.Map
+	generations atomic.Uint64
+)
+
+// sentinelFor returns the leaf node shared by every map with the same key
+// and value types. Sharing it lets Split and Join move subtrees between
+// maps without touching their leaves; the sentinel is never written to.
+func sentinelFor[K any, V any]() *Node[K, V] {
+	t := reflect.TypeFor[Node[K, V]]()
+	if sentinel, ok := sentinels.Load(t); ok {
+		return sentinel.(*Node[K, V])
+	}
+	sentinel, _ := sentinels.LoadOrStore(t, &Node[K, V]{color: BLACK})
+	return sentinel.(*Node[K, V])
+}
+
+// nextGen returns a generation that no map has used before.
+func nextGen() uint64 {
+	return generations.Add(1)
+}
+
+// NewWithComparator creates a map ordered by a three-way comparator that
+// returns a negative number when a < b, zero when a and b are equivalent
+// and a positive number when a > b.
+func NewWithComparator[K any, V any](compare func(a, b K) int) *RBTreeMap[K, V] {
+	sentinel := sentinelFor[K, V]()
+	return &RBTreeMap[K, V]{
+		root:     sentinel,
+		sentinel: sentinel,
+		gen:      nextGen(),
+		compare:  compare,
+	}
+}
+
+func (r *RBTreeMap[K, V]) Size() int {
+	return r.size
+}
+
+func (r *RBTreeMap[K, V]) search(key K) *Node[K, V] {
+	current := r.root
+	for current != r.sentinel {
+		c := r.compare(key, current.key)
+		if c == 0 {
+			return current
+		}
+		if c < 0 {
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+	return r.sentinel
+}
+
+func (r *RBTreeMap[K, V]) Insert(key K, value V) {
+	if node := r.attach(key, value); node != r.sentinel {
+		r.fixInsert(node)
+	}
+}
+
+// attach replaces the value of key if it is present and returns the
+// sentinel. Otherwise it links a new red leaf for key and returns it,
+// leaving the caller to restore the red-black properties with fixInsert.
+func (r *RBTreeMap[K, V]) attach(key K, value V) *Node[K, V] {
+	parent := r.sentinel
+	current := r.root
+
+	c := 0
+	for current != r.sentinel {
+		parent = current
+		c = r.compare(key, current.key)
+		if c == 0 {
+			current = r.mutable(current)
+			current.value = value
+			r.augmentPath(current)
+			return r.sentinel
+		}
+		if c < 0 {
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+
+	parent = r.mutable(parent)
+	newNode := &Node[K, V]{
+		key:    key,
+		value:  value,
+		color:  RED,
+		size:   1,
+		parent: parent,
+		left:   r.sentinel,
+		right:  r.sentinel,
+		gen:    r.gen,
+	}
+	if parent == r.sentinel {
+		r.root = newNode
+	} else if c < 0 {
+		parent.left = newNode
+	} else {
+		parent.right = newNode
+	}
+	for p := parent; p != r.sentinel; p = p.parent {
+		p.size++
+	}
+	r.augmentPath(newNode)
+
+	r.size++
+	r.mod++
+	return newNode
+}
+
+func (r *RBTreeMap[K, V]) Get(key K) (V, bool) {
+	node := r.search(key)
+	if node != r.sentinel {
+		return node.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (r *RBTreeMap[K, V]) Remove(key K) {
+	z := r.search(key)
+	if z == r.sentinel {
+		return
+	}
+	r.deleteNode(z)
+}
+
+func (r *RBTreeMap[K, V]) deleteNode(z *Node[K, V]) {
+	if x, xParent, black := r.unlink(z); black {
+		r.fixDelete(x, xParent)
+	}
+}
+
+// unlink takes z out of the tree, moving its successor into its place when
+// it has two children. It returns the node left in the place of the one
+// that was taken out, with its parent, and whether fixDelete is needed.
+func (r *RBTreeMap[K, V]) unlink(z *Node[K, V]) (x, xParent *Node[K, V], black bool) {
+	r.size--
+	r.mod++
+
+	z = r.mutable(z)
+	y := z
+	if z.left != r.sentinel && z.right != r.sentinel {
+		y = r.mutable(r.minimum(z.right))
+	}
+	yOriginalColor := y.color
+	for p := y.parent; p != r.sentinel; p = p.parent {
+		p.size--
+	}
+
+	if z.left == r.sentinel {
+		x, xParent = z.right, z.parent
+		r.transplant(z, z.right)
+	} else if z.right == r.sentinel {
+		x, xParent = z.left, z.parent
+		r.transplant(z, z.left)
+	} else {
+		x = y.right
+		if y.parent == z {
+			xParent = y
+		} else {
+			xParent = y.parent
+			r.transplant(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		r.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.color = z.color
+		y.size = z.size
+	}
+	r.augmentPath(xParent)
+
+	// A snapshot may still reach z through the parent pointer of a shared
+	// node, so drop its links to keep it from holding on to the live tree.
+	z.parent, z.left, z.right = nil, nil, nil
+	return x, xParent, yOriginalColor == BLACK
+}
+
+// PopMin removes the entry with the smallest key and returns it.
+func (r *RBTreeMap[K, V]) PopMin() (K, V, bool) {
+	if r.root == r.sentinel {
+		return r.entry(r.sentinel)
+	}
+	node := r.minimum(r.root)
+	r.deleteNode(node)
+	return node.key, node.value, true
+}
+
+// PopMax removes the entry with the largest key and returns it.
+func (r *RBTreeMap[K, V]) PopMax() (K, V, bool) {
+	if r.root == r.sentinel {
+		return r.entry(r.sentinel)
+	}
+	node := r.maximum(r.root)
+	r.deleteNode(node)
+	return node.key, node.value, true
+}
+
+func (r *RBTreeMap[K, V]) ContainsKey(key K) bool {
+	return r.search(key) != r.sentinel
+}
+
+func (r *RBTreeMap[K, V]) InOrder() iter.Seq2[K, V] {
+	return r.ascend(bounds[K]{})
+}
+
+func (r *RBTreeMap[K, V]) Backward() iter.Seq2[K, V] {
+	return r.descend(bounds[K]{})
+}
+
+func (r *RBTreeMap[K, V]) LowerBound(key K) (K, V, bool) {
+	return r.entry(r.lowerBoundNode(key))
+}
+
+func (r *RBTreeMap[K, V]) UpperBound(key K) (K, V, bool) {
+	return r.entry(r.upperBoundNode(key))
+}
+
+// Ceiling returns the entry with the least key greater than or equal to key.
+func (r *RBTreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return r.entry(r.lowerBoundNode(key))
+}
+
+// Higher returns the entry with the least key strictly greater than key.
+func (r *RBTreeMap[K, V]) Higher(key K) (K, V, bool) {
+	return r.entry(r.upperBoundNode(key))
+}
+
+// Floor returns the entry with the greatest key less than or equal to key.
+func (r *RBTreeMap[K, V]) Floor(key K) (K, V, bool) {
+	return r.entry(r.floorNode(key))
+}
+
+// Lower returns the entry with the greatest key strictly less than key.
+func (r *RBTreeMap[K, V]) Lower(key K) (K, V, bool) {
+	return r.entry(r.lowerNode(key))
+}
+
+func (r *RBTreeMap[K, V]) Min() (K, V, bool) {
+	if r.root == r.sentinel {
+		return r.entry(r.sentinel)
+	}
+	return r.entry(r.minimum(r.root))
+}
+
+func (r *RBTreeMap[K, V]) Max() (K, V, bool) {
+	if r.root == r.sentinel {
+		return r.entry(r.sentinel)
+	}
+	return r.entry(r.maximum(r.root))
+}
+
+func (r *RBTreeMap[K, V]) entry(node *Node[K, V]) (K, V, bool) {
+	if node != r.sentinel {
+		return node.key, node.value, true
+	}
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
+}
+
+func (r *RBTreeMap[K, V]) lowerBoundNode(key K) *Node[K, V] {
+	result := r.sentinel
+	current := r.root
+
+	for current != r.sentinel {
+		if r.compare(current.key, key) >= 0 {
+			result = current
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+	return result
+}
+
+func (r *RBTreeMap[K, V]) upperBoundNode(key K) *Node[K, V] {
+	result := r.sentinel
+	current := r.root
+
+	for current != r.sentinel {
+		if r.compare(key, current.key) < 0 {
+			result = current
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+	return result
+}
+
+func (r *RBTreeMap[K, V]) floorNode(key K) *Node[K, V] {
+	result := r.sentinel
+	current := r.root
+
+	for current != r.sentinel {
+		if r.compare(current.key, key) <= 0 {
+			result = current
+			current = current.right
+		} else {
+			current = current.left
+		}
+	}
+	return result
+}
+
+func (r *RBTreeMap[K, V]) lowerNode(key K) *Node[K, V] {
+	result := r.sentinel
+	current := r.root
+
+	for current != r.sentinel {
+		if r.compare(current.key, key) < 0 {
+			result = current
+			current = current.right
+		} else {
+			current = current.left
+		}
+	}
+	return result
+}
+
+// Rank returns the number of keys in the map that are less than key.
+func (r *RBTreeMap[K, V]) Rank(key K) int {
+	rank := 0
+	current := r.root
+	for current != r.sentinel {
+		if r.compare(key, current.key) <= 0 {
+			current = current.left
+		} else {
+			rank += current.left.size + 1
+			current = current.right
+		}
+	}
+	return rank
+}
+
+// Select returns the entry with the given zero-based position in key order.
+func (r *RBTreeMap[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= r.size {
+		return r.entry(r.sentinel)
+	}
+	current := r.root
+	for {
+		leftSize := current.left.size
+		if i < leftSize {
+			current = current.left
+		} else if i == leftSize {
+			return current.key, current.value, true
+		} else {
+			i -= leftSize + 1
+			current = current.right
+		}
+	}
+}
+
+func (r *RBTreeMap[K, V]) checkMod(mod int) {
+	if r.mod != mod {
+		panic("rbtree: map modified during iteration")
+	}
+}
+
+// mutable returns a copy of node that belongs to the current generation,
+// along with all of its ancestors, and links the copy into the tree in
+// place of node. Nodes that already belong to the current generation are
+// returned as is.
+func (r *RBTreeMap[K, V]) mutable(node *Node[K, V]) *Node[K, V] {
+	if node == r.sentinel || node.gen == r.gen {
+		return node
+	}
+	parent := r.mutable(node.parent)
+	copied := r.own(node)
+	copied.parent = parent
+	if parent == r.sentinel {
+		r.root = copied
+	} else if parent.left == node {
+		parent.left = copied
+	} else {
+		parent.right = copied
+	}
+	return copied
+}
+
+// own is like mutable but leaves linking the copy to a parent to the
+// caller. It is used for nodes that are being detached from the tree.
+func (r *RBTreeMap[K, V]) own(node *Node[K, V]) *Node[K, V] {
+	if node == r.sentinel || node.gen == r.gen {
+		return node
+	}
+	copied := *node
+	copied.gen = r.gen
+	copied.replaced = false
+	if copied.left != r.sentinel {
+		copied.left.parent = &copied
+	}
+	if copied.right != r.sentinel {
+		copied.right.parent = &copied
+	}
+	// The original stays in the snapshots that share it, which never read
+	// parent, so clearing it keeps them from pinning later live nodes.
+	node.replaced = true
+	node.parent = nil
+	return &copied
+}
+
+// live returns the node with the same key that is currently part of the
+// tree, looking it up again if node has been copied since it was reached.
+// Frozen snapshot trees keep reading their own nodes.
+func (r *RBTreeMap[K, V]) live(node *Node[K, V]) *Node[K, V] {
+	if r.frozen || !node.replaced {
+		return node
+	}
+	return r.search(node.key)
+}
+
+func (r *RBTreeMap[K, V]) minimum(node *Node[K, V]) *Node[K, V] {
+	for node.left != r.sentinel {
+		node = node.left
+	}
+	return node
+}
+
+func (r *RBTreeMap[K, V]) maximum(node *Node[K, V]) *Node[K, V] {
+	for node.right != r.sentinel {
+		node = node.right
+	}
+	return node
+}
+
+func (r *RBTreeMap[K, V]) successor(node *Node[K, V]) *Node[K, V] {
+	if node.right != r.sentinel {
+		return r.minimum(node.right)
+	}
+	parent := node.parent
+	for parent != r.sentinel && node == parent.right {
+		node = parent
+		parent = parent.parent
+	}
+	return parent
+}
+
+func (r *RBTreeMap[K, V]) predecessor(node *Node[K, V]) *Node[K, V] {
+	if node.left != r.sentinel {
+		return r.maximum(node.left)
+	}
+	parent := node.parent
+	for parent != r.sentinel && node == parent.left {
+		node = parent
+		parent = parent.parent
+	}
+	return parent
+}
+
+// fixInsert reports whether the black height of the tree grew.
+func (r *RBTreeMap[K, V]) fixInsert(node *Node[K, V]) bool {
+	for node.parent.color == RED {
+		if node.parent == node.parent.parent.left {
+			uncle := node.parent.parent.right
+			if uncle.color == RED {
+				node.parent.color = BLACK
+				r.mutable(uncle).color = BLACK
+				node.parent.parent.color = RED
+				node = node.parent.parent
+			} else {
+				if node == node.parent.right {
+					node = node.parent
+					r.rotateLeft(node)
+				}
+				node.parent.color = BLACK
+				node.parent.parent.color = RED
+				r.rotateRight(node.parent.parent)
+			}
+		} else {
+			uncle := node.parent.parent.left
+			if uncle.color == RED {
+				node.parent.color = BLACK
+				r.mutable(uncle).color = BLACK
+				node.parent.parent.color = RED
+				node = node.parent.parent
+			} else {
+				if node == node.parent.left {
+					node = node.parent
+					r.rotateRight(node)
+				}
+				node.parent.color = BLACK
+				node.parent.parent.color = RED
+				r.rotateLeft(node.parent.parent)
+			}
+		}
+	}
+	root := r.mutable(r.root)
+	grew := root.color == RED
+	root.color = BLACK
+	return grew
+}
+
+func (r *RBTreeMap[K, V]) rotateLeft(x *Node[K, V]) {
+	x = r.mutable(x)
+	y := r.mutable(x.right)
+	x.right = y.left
+	if y.left != r.sentinel {
+		y.left.parent = x
+	}
+	y.parent = x.parent
+	if x.parent == r.sentinel {
+		r.root = y
+	} else if x == x.parent.left {
+		x.parent.left = y
+	} else {
+		x.parent.right = y
+	}
+	y.left = x
+	x.parent = y
+	y.size = x.size
+	x.size = x.left.size + x.right.size + 1
+	r.augmentNode(x)
+	r.augmentNode(y)
+	if r.rotated != nil {
+		r.rotated(y, x)
+	}
+}
+
+func (r *RBTreeMap[K, V]) rotateRight(y *Node[K, V]) {
+	y = r.mutable(y)
+	x := r.mutable(y.left)
+	y.left = x.right
+	if x.right != r.sentinel {
+		x.right.parent = y
+	}
+	x.parent = y.parent
+	if y.parent == r.sentinel {
+		r.root = x
+	} else if y == y.parent.left {
+		y.parent.left = x
+	} else {
+		y.parent.right = x
+	}
+	x.right = y
+	y.parent = x
+	x.size = y.size
+	y.size = y.left.size + y.right.size + 1
+	r.augmentNode(y)
+	r.augmentNode(x)
+	if r.rotated != nil {
+		r.rotated(x, y)
+	}
+}
+
+func (r *RBTreeMap[K, V]) augmentNode(node *Node[K, V]) {
+	if r.augment != nil {
+		r.augment(node)
+	}
+}
+
+// augmentPath recomputes node and all of its ancestors.
+func (r *RBTreeMap[K, V]) augmentPath(node *Node[K, V]) {
+	if r.augment == nil {
+		return
+	}
+	for ; node != r.sentinel; node = node.parent {
+		r.augment(node)
+	}
+}
+
+func (r *RBTreeMap[K, V]) transplant(u, v *Node[K, V]) {
+	if u.parent == r.sentinel {
+		r.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+	if v != r.sentinel {
+		v.parent = u.parent
+	}
+}
+
+// fixDelete takes the parent of x explicitly because x may be the sentinel,
+// which is shared by every node and never written to.
+func (r *RBTreeMap[K, V]) fixDelete(x, parent *Node[K, V]) {
+	for x != r.root && x.color == BLACK {
+		if x == parent.left {
+			sibling := r.mutable(parent.right)
+			if sibling.color == RED {
+				sibling.color = BLACK
+				parent.color = RED
+				r.rotateLeft(parent)
+				sibling = r.mutable(parent.right)
+			}
+			if sibling.left.color == BLACK && sibling.right.color == BLACK {
+				sibling.color = RED
+				x = parent
+				parent = x.parent
+			} else {
+				if sibling.right.color == BLACK {
+					r.mutable(sibling.left).color = BLACK
+					sibling.color = RED
+					r.rotateRight(sibling)
+					sibling = r.mutable(parent.right)
+				}
+				sibling.color = parent.color
+				parent.color = BLACK
+				r.mutable(sibling.right).color = BLACK
+				r.rotateLeft(parent)
+				x = r.root
+			}
+		} else {
+			sibling := r.mutable(parent.left)
+			if sibling.color == RED {
+				sibling.color = BLACK
+				parent.color = RED
+				r.rotateRight(parent)
+				sibling = r.mutable(parent.left)
+			}
+			if sibling.right.color == BLACK && sibling.left.color == BLACK {
+				sibling.color = RED
+				x = parent
+				parent = x.parent
+			} else {
+				if sibling.left.color == BLACK {
+					r.mutable(sibling.right).color = BLACK
+					sibling.color = RED
+					r.rotateLeft(sibling)
+					sibling = r.mutable(parent.left)
+				}
+				sibling.color = parent.color
+				parent.color = BLACK
+				r.mutable(sibling.left).color = BLACK
+				r.rotateRight(parent)
+				x = r.root
+			}
+		}
+	}
+	if x != r.sentinel {
+		r.mutable(x).color = BLACK
+	}
+}
@@ -0,0 +1,346 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Monoid describes an aggregate over the values of a key range. Lift turns
+// one value into an aggregate and Combine merges the aggregates of two
+// adjacent ranges, the lower range first. Combine must be associative with
+// Identity as its neutral element. Lift may be nil when V and A are the
+// same type; otherwise the constructors panic.
+type Monoid[V any, A any] struct {
+	Identity A
+	Lift     func(value V) A
+	Combine  func(a, b A) A
+}
+
+type aggregateValue[V any, A any] struct {
+	value V
+	// agg is the aggregate of every value in the node's subtree.
+	agg A
+}
+
+// AggregateMap is an ordered map that keeps a Monoid aggregate of every
+// subtree, so the aggregate of any key range is found in O(log n).
+type AggregateMap[K any, V any, A any] struct {
+	tree   *RBTreeMap[K, aggregateValue[V, A]]
+	monoid Monoid[V, A]
+}
+
+func NewWithMonoid[K cmp.Ordered, V any, A any](monoid Monoid[V, A]) *AggregateMap[K, V, A] {
+	return NewWithMonoidAndComparator[K](cmp.Compare[K], monoid)
+}
+
+func NewWithMonoidAndComparator[K any, V any, A any](compare func(a, b K) int, monoid Monoid[V, A]) *AggregateMap[K, V, A] {
+	if monoid.Lift == nil {
+		// The assertion only holds when V and A are the same type.
+		lift, ok := any(func(value V) V { return value }).(func(V) A)
+		if !ok {
+			panic("rbtree: Monoid.Lift is nil but the value and aggregate types differ")
+		}
+		monoid.Lift = lift
+	}
+	m := &AggregateMap[K, V, A]{
+		tree:   NewWithComparator[K, aggregateValue[V, A]](compare),
+		monoid: monoid,
+	}
+	m.tree.augment = m.updateAggregate
+	return m
+}
+
+func (m *AggregateMap[K, V, A]) updateAggregate(node *Node[K, aggregateValue[V, A]]) {
+	agg := m.monoid.Lift(node.value.value)
+	if node.left != m.tree.sentinel {
+		agg = m.monoid.Combine(node.left.value.agg, agg)
+	}
+	if node.right != m.tree.sentinel {
+		agg = m.monoid.Combine(agg, node.right.value.agg)
+	}
+	node.value.agg = agg
+}
+
+func (m *AggregateMap[K, V, A]) Size() int {
+	return m.tree.Size()
+}
+
+func (m *AggregateMap[K, V, A]) Insert(key K, value V) {
+	m.tree.Insert(key, aggregateValue[V, A]{value: value})
+}
+
+func (m *AggregateMap[K, V, A]) Get(key K) (V, bool) {
+	v, ok := m.tree.Get(key)
+	return v.value, ok
+}
+
+func (m *AggregateMap[K, V, A]) Remove(key K) {
+	m.tree.Remove(key)
+}
+
+func (m *AggregateMap[K, V, A]) ContainsKey(key K) bool {
+	return m.tree.ContainsKey(key)
+}
+
+func (m *AggregateMap[K, V, A]) PopMin() (K, V, bool) {
+	return aggregateEntry(m.tree.PopMin())
+}
+
+func (m *AggregateMap[K, V, A]) PopMax() (K, V, bool) {
+	return aggregateEntry(m.tree.PopMax())
+}
+
+func (m *AggregateMap[K, V, A]) LowerBound(key K) (K, V, bool) {
+	return aggregateEntry(m.tree.LowerBound(key))
+}
+
+func (m *AggregateMap[K, V, A]) UpperBound(key K) (K, V, bool) {
+	return aggregateEntry(m.tree.UpperBound(key))
+}
+
+func (m *AggregateMap[K, V, A]) Ceiling(key K) (K, V, bool) {
+	return aggregateEntry(m.tree.Ceiling(key))
+}
+
+func (m *AggregateMap[K, V, A]) Higher(key K) (K, V, bool) {
+	return aggregateEntry(m.tree.Higher(key))
+}
+
+func (m *AggregateMap[K, V, A]) Floor(key K) (K, V, bool) {
+	return aggregateEntry(m.tree.Floor(key))
+}
+
+func (m *AggregateMap[K, V, A]) Lower(key K) (K, V, bool) {
+	return aggregateEntry(m.tree.Lower(key))
+}
+
+func (m *AggregateMap[K, V, A]) Min() (K, V, bool) {
+	return aggregateEntry(m.tree.Min())
+}
+
+func (m *AggregateMap[K, V, A]) Max() (K, V, bool) {
+	return aggregateEntry(m.tree.Max())
+}
+
+func (m *AggregateMap[K, V, A]) Rank(key K) int {
+	return m.tree.Rank(key)
+}
+
+func (m *AggregateMap[K, V, A]) Select(i int) (K, V, bool) {
+	return aggregateEntry(m.tree.Select(i))
+}
+
+func (m *AggregateMap[K, V, A]) CountRange(lo, hi K, opts ...RangeOption) int {
+	return m.tree.CountRange(lo, hi, opts...)
+}
+
+func (m *AggregateMap[K, V, A]) InOrder() iter.Seq2[K, V] {
+	return aggregateValues(m.tree.InOrder())
+}
+
+func (m *AggregateMap[K, V, A]) Backward() iter.Seq2[K, V] {
+	return aggregateValues(m.tree.Backward())
+}
+
+// Range iterates over the entries with keys in [lo, hi), like
+// RBTreeMap.Range.
+func (m *AggregateMap[K, V, A]) Range(lo, hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	return aggregateValues(m.tree.Range(lo, hi, opts...))
+}
+
+func (m *AggregateMap[K, V, A]) RangeFrom(lo K, opts ...RangeOption) iter.Seq2[K, V] {
+	return aggregateValues(m.tree.RangeFrom(lo, opts...))
+}
+
+func (m *AggregateMap[K, V, A]) RangeTo(hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	return aggregateValues(m.tree.RangeTo(hi, opts...))
+}
+
+func (m *AggregateMap[K, V, A]) RangeBackward(lo, hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	return aggregateValues(m.tree.RangeBackward(lo, hi, opts...))
+}
+
+func (m *AggregateMap[K, V, A]) RangeFromBackward(lo K, opts ...RangeOption) iter.Seq2[K, V] {
+	return aggregateValues(m.tree.RangeFromBackward(lo, opts...))
+}
+
+func (m *AggregateMap[K, V, A]) RangeToBackward(hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	return aggregateValues(m.tree.RangeToBackward(hi, opts...))
+}
+
+func aggregateEntry[K any, V any, A any](key K, value aggregateValue[V, A], ok bool) (K, V, bool) {
+	return key, value.value, ok
+}
+
+func aggregateValues[K any, V any, A any](seq iter.Seq2[K, aggregateValue[V, A]]) iter.Seq2[K, V] {
+	return func(yield func(key K, value V) bool) {
+		for k, v := range seq {
+			if !yield(k, v.value) {
+				return
+			}
+		}
+	}
+}
+
+// AggregateAll returns the aggregate of every value in the map in O(1).
+func (m *AggregateMap[K, V, A]) AggregateAll() A {
+	return subtreeAggregate(m.tree, m.monoid, m.tree.root)
+}
+
+// Aggregate returns the aggregate of the values with keys in [lo, hi) in
+// O(log n). The options change which bounds are included, as in Range.
+func (m *AggregateMap[K, V, A]) Aggregate(lo, hi K, opts ...RangeOption) A {
+	return rangeAggregate(m.tree, m.monoid, lo, hi, opts)
+}
+
+func subtreeAggregate[K any, V any, A any](r *RBTreeMap[K, aggregateValue[V, A]], monoid Monoid[V, A], node *Node[K, aggregateValue[V, A]]) A {
+	if node == r.sentinel {
+		return monoid.Identity
+	}
+	return node.value.agg
+}
+
+func rangeAggregate[K any, V any, A any](r *RBTreeMap[K, aggregateValue[V, A]], monoid Monoid[V, A], lo, hi K, opts []RangeOption) A {
+	b := newBounds[K](opts)
+	b.lo, b.hasLo = lo, true
+	b.hi, b.hasHi = hi, true
+
+	// Find the highest node inside the range. Its left subtree holds the
+	// part of the range below it and its right subtree the part above.
+	node := r.root
+	for node != r.sentinel {
+		if !r.aboveLo(b, node.key) {
+			node = node.right
+		} else if !r.belowHi(b, node.key) {
+			node = node.left
+		} else {
+			break
+		}
+	}
+	if node == r.sentinel {
+		return monoid.Identity
+	}
+
+	lower := monoid.Identity
+	for current := node.left; current != r.sentinel; {
+		if r.aboveLo(b, current.key) {
+			part := monoid.Combine(monoid.Lift(current.value.value), subtreeAggregate(r, monoid, current.right))
+			lower = monoid.Combine(part, lower)
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+
+	upper := monoid.Identity
+	for current := node.right; current != r.sentinel; {
+		if r.belowHi(b, current.key) {
+			part := monoid.Combine(subtreeAggregate(r, monoid, current.left), monoid.Lift(current.value.value))
+			upper = monoid.Combine(upper, part)
+			current = current.right
+		} else {
+			current = current.left
+		}
+	}
+
+	return monoid.Combine(monoid.Combine(lower, monoid.Lift(node.value.value)), upper)
+}
+
+// Cursor returns a cursor over the map. Setting a value or deleting an
+// entry through it keeps the aggregates up to date.
+func (m *AggregateMap[K, V, A]) Cursor() *AggregateCursor[K, V, A] {
+	return &AggregateCursor[K, V, A]{cursor: m.tree.Cursor()}
+}
+
+// AggregateCursor is a Cursor over an AggregateMap.
+type AggregateCursor[K any, V any, A any] struct {
+	cursor *Cursor[K, aggregateValue[V, A]]
+}
+
+func (c *AggregateCursor[K, V, A]) Valid() bool {
+	return c.cursor.Valid()
+}
+
+func (c *AggregateCursor[K, V, A]) First() bool {
+	return c.cursor.First()
+}
+
+func (c *AggregateCursor[K, V, A]) Last() bool {
+	return c.cursor.Last()
+}
+
+// Seek moves the cursor to the first entry whose key is not less than key.
+func (c *AggregateCursor[K, V, A]) Seek(key K) bool {
+	return c.cursor.Seek(key)
+}
+
+func (c *AggregateCursor[K, V, A]) Next() bool {
+	return c.cursor.Next()
+}
+
+func (c *AggregateCursor[K, V, A]) Prev() bool {
+	return c.cursor.Prev()
+}
+
+func (c *AggregateCursor[K, V, A]) Key() K {
+	return c.cursor.Key()
+}
+
+func (c *AggregateCursor[K, V, A]) Value() V {
+	return c.cursor.Value().value
+}
+
+func (c *AggregateCursor[K, V, A]) SetValue(value V) {
+	c.cursor.SetValue(aggregateValue[V, A]{value: value})
+}
+
+// Delete removes the current entry and moves the cursor to the entry that
+// followed it.
+func (c *AggregateCursor[K, V, A]) Delete() {
+	c.cursor.Delete()
+}
+
+// Snapshot returns a frozen view of the map in O(1), like
+// RBTreeMap.Snapshot. The view answers aggregate queries too.
+func (m *AggregateMap[K, V, A]) Snapshot() *AggregateSnapshot[K, V, A] {
+	return &AggregateSnapshot[K, V, A]{snapshot: m.tree.Snapshot(), monoid: m.monoid}
+}
+
+// AggregateSnapshot is a read-only view of an AggregateMap.
+type AggregateSnapshot[K any, V any, A any] struct {
+	snapshot *Snapshot[K, aggregateValue[V, A]]
+	monoid   Monoid[V, A]
+}
+
+func (s *AggregateSnapshot[K, V, A]) Size() int {
+	return s.snapshot.Size()
+}
+
+func (s *AggregateSnapshot[K, V, A]) Get(key K) (V, bool) {
+	v, ok := s.snapshot.Get(key)
+	return v.value, ok
+}
+
+func (s *AggregateSnapshot[K, V, A]) ContainsKey(key K) bool {
+	return s.snapshot.ContainsKey(key)
+}
+
+func (s *AggregateSnapshot[K, V, A]) InOrder() iter.Seq2[K, V] {
+	return aggregateValues(s.snapshot.InOrder())
+}
+
+func (s *AggregateSnapshot[K, V, A]) LowerBound(key K) (K, V, bool) {
+	return aggregateEntry(s.snapshot.LowerBound(key))
+}
+
+func (s *AggregateSnapshot[K, V, A]) UpperBound(key K) (K, V, bool) {
+	return aggregateEntry(s.snapshot.UpperBound(key))
+}
+
+func (s *AggregateSnapshot[K, V, A]) AggregateAll() A {
+	return subtreeAggregate(s.snapshot.tree, s.monoid, s.snapshot.tree.root)
+}
+
+func (s *AggregateSnapshot[K, V, A]) Aggregate(lo, hi K, opts ...RangeOption) A {
+	return rangeAggregate(s.snapshot.tree, s.monoid, lo, hi, opts)
+}
@@ -0,0 +1,286 @@
+package rbtree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Codec writes and reads single keys or values in the binary format used by
+// WriteTo and ReadFrom. The reader passed to Decode always implements
+// io.ByteReader.
+type Codec[T any] interface {
+	Encode(w io.Writer, v T) error
+	Decode(r io.Reader) (T, error)
+}
+
+// DefaultCodec returns the codec used when none is set with SetCodecs. It
+// handles strings, byte slices, int and uint, types implementing
+// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler, and any
+// fixed-size type supported by encoding/binary. Its Decode buffers a
+// reader that does not implement io.ByteReader, so it may read past the
+// decoded value.
+func DefaultCodec[T any]() Codec[T] {
+	return defaultCodec[T]{}
+}
+
+// SetCodecs sets the codecs used to serialize keys and values. A nil codec
+// means DefaultCodec.
+func (r *RBTreeMap[K, V]) SetCodecs(key Codec[K], value Codec[V]) {
+	r.keyCodec = key
+	r.valueCodec = value
+}
+
+// The binary format is a header made of binaryMagic and binaryVersion, the
+// number of entries as a uvarint, and then every key followed by its value
+// in ascending key order.
+const (
+	binaryMagic   = "RBTM"
+	binaryVersion = 1
+)
+
+var ErrInvalidFormat = errors.New("rbtree: invalid binary format")
+
+func (r *RBTreeMap[K, V]) codecs() (Codec[K], Codec[V]) {
+	key, value := r.keyCodec, r.valueCodec
+	if key == nil {
+		key = DefaultCodec[K]()
+	}
+	if value == nil {
+		value = DefaultCodec[V]()
+	}
+	return key, value
+}
+
+// WriteTo writes the map to w and returns the number of bytes written.
+func (r *RBTreeMap[K, V]) WriteTo(w io.Writer) (int64, error) {
+	counter := &countingWriter{w: w}
+	bw := bufio.NewWriter(counter)
+	keyCodec, valueCodec := r.codecs()
+
+	bw.WriteString(binaryMagic)
+	bw.WriteByte(binaryVersion)
+	bw.Write(binary.AppendUvarint(nil, uint64(r.size)))
+	for k, v := range r.InOrder() {
+		if err := keyCodec.Encode(bw, k); err != nil {
+			return counter.n, err
+		}
+		if err := valueCodec.Encode(bw, v); err != nil {
+			return counter.n, err
+		}
+	}
+	err := bw.Flush()
+	return counter.n, err
+}
+
+// ReadFrom replaces the contents of the map with a map read from r and
+// returns the number of bytes read. A zero RBTreeMap can be read into when
+// its keys are ordered. The entries must be in ascending order
+// under the map's comparator, which lets the tree be rebuilt in O(n). If r
+// does not implement io.ByteReader it is buffered, so ReadFrom may read
+// past the end of the map.
+func (r *RBTreeMap[K, V]) ReadFrom(rd io.Reader) (int64, error) {
+	if err := r.initZero(); err != nil {
+		return 0, err
+	}
+	counter := &countingReader{r: rd}
+	var br io.Reader
+	if _, ok := rd.(io.ByteReader); ok {
+		br = byteCountingReader{counter}
+	} else {
+		br = bufio.NewReader(counter)
+	}
+	keyCodec, valueCodec := r.codecs()
+
+	header := make([]byte, len(binaryMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return counter.n, fmt.Errorf("%w: %w", ErrInvalidFormat, unexpectedEOF(err))
+	}
+	if string(header[:len(binaryMagic)]) != binaryMagic {
+		return counter.n, fmt.Errorf("%w: bad magic %q", ErrInvalidFormat, header[:len(binaryMagic)])
+	}
+	if version := header[len(binaryMagic)]; version != binaryVersion {
+		return counter.n, fmt.Errorf("%w: unsupported version %d", ErrInvalidFormat, version)
+	}
+	count, err := binary.ReadUvarint(br.(io.ByteReader))
+	if err != nil {
+		return counter.n, fmt.Errorf("%w: %w", ErrInvalidFormat, unexpectedEOF(err))
+	}
+
+	gen := nextGen()
+	nodes := make([]*Node[K, V], 0, min(count, 1<<16))
+	for i := uint64(0); i < count; i++ {
+		key, err := keyCodec.Decode(br)
+		if err != nil {
+			return counter.n, fmt.Errorf("rbtree: decoding key %d: %w", i, unexpectedEOF(err))
+		}
+		value, err := valueCodec.Decode(br)
+		if err != nil {
+			return counter.n, fmt.Errorf("rbtree: decoding value %d: %w", i, unexpectedEOF(err))
+		}
+		if len(nodes) > 0 {
+			if err := r.checkSorted(nodes[len(nodes)-1].key, key, len(nodes)); err != nil {
+				return counter.n, err
+			}
+		}
+		nodes = append(nodes, &Node[K, V]{key: key, value: value, gen: gen})
+	}
+
+	r.gen = gen
+	r.buildSorted(nodes)
+	return counter.n, nil
+}
+
+func (r *RBTreeMap[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := r.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *RBTreeMap[K, V]) UnmarshalBinary(data []byte) error {
+	rd := bytes.NewReader(data)
+	if _, err := r.ReadFrom(rd); err != nil {
+		return err
+	}
+	if rd.Len() != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidFormat, rd.Len())
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// byteCountingReader is used when the underlying reader can already read
+// single bytes, so nothing past the end of the map is consumed.
+type byteCountingReader struct {
+	*countingReader
+}
+
+func (b byteCountingReader) ReadByte() (byte, error) {
+	c, err := b.r.(io.ByteReader).ReadByte()
+	if err == nil {
+		b.n++
+	}
+	return c, err
+}
+
+type defaultCodec[T any] struct{}
+
+func (defaultCodec[T]) Encode(w io.Writer, v T) error {
+	var buf []byte
+	switch x := any(v).(type) {
+	case encoding.BinaryMarshaler:
+		data, err := x.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(data)))
+		buf = append(buf, data...)
+	case string:
+		buf = binary.AppendUvarint(buf, uint64(len(x)))
+		buf = append(buf, x...)
+	case []byte:
+		buf = binary.AppendUvarint(buf, uint64(len(x)))
+		buf = append(buf, x...)
+	case int:
+		buf = binary.AppendVarint(buf, int64(x))
+	case uint:
+		buf = binary.AppendUvarint(buf, uint64(x))
+	default:
+		if binary.Size(v) < 0 {
+			return fmt.Errorf("rbtree: no default encoding for %T, set a codec with SetCodecs", v)
+		}
+		return binary.Write(w, binary.LittleEndian, v)
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+func (defaultCodec[T]) Decode(r io.Reader) (T, error) {
+	var v T
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		buffered := bufio.NewReader(r)
+		r, br = buffered, buffered
+	}
+	switch p := any(&v).(type) {
+	case encoding.BinaryUnmarshaler:
+		data, err := readBytes(r, br)
+		if err != nil {
+			return v, err
+		}
+		err = p.UnmarshalBinary(data)
+		return v, err
+	case *string:
+		data, err := readBytes(r, br)
+		*p = string(data)
+		return v, err
+	case *[]byte:
+		data, err := readBytes(r, br)
+		*p = data
+		return v, err
+	case *int:
+		x, err := binary.ReadVarint(br)
+		*p = int(x)
+		return v, err
+	case *uint:
+		x, err := binary.ReadUvarint(br)
+		*p = uint(x)
+		return v, err
+	}
+	if binary.Size(v) < 0 {
+		return v, fmt.Errorf("rbtree: no default decoding for %T, set a codec with SetCodecs", v)
+	}
+	err := binary.Read(r, binary.LittleEndian, &v)
+	return v, err
+}
+
+func readBytes(r io.Reader, br io.ByteReader) ([]byte, error) {
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 1<<16 {
+		data := make([]byte, n)
+		_, err = io.ReadFull(r, data)
+		return data, err
+	}
+	// Grow the buffer as the data arrives so that a corrupt length can't
+	// force a huge allocation.
+	var buf bytes.Buffer
+	_, err = io.CopyN(&buf, r, int64(n))
+	return buf.Bytes(), unexpectedEOF(err)
+}
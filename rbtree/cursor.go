@@ -0,0 +1,95 @@
+package rbtree
+
+// Cursor is a position in an RBTreeMap that can be moved in both
+// directions. A new cursor is not positioned; call First, Last or Seek
+// before reading from it. Changing the map other than through the cursor
+// invalidates it, and the next step panics.
+type Cursor[K any, V any] struct {
+	tree *RBTreeMap[K, V]
+	node *Node[K, V]
+	mod  int
+}
+
+func (r *RBTreeMap[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{tree: r, node: r.sentinel, mod: r.mod}
+}
+
+func (c *Cursor[K, V]) Valid() bool {
+	return c.node != c.tree.sentinel
+}
+
+func (c *Cursor[K, V]) First() bool {
+	c.node = c.tree.sentinel
+	c.mod = c.tree.mod
+	if c.tree.root != c.tree.sentinel {
+		c.node = c.tree.minimum(c.tree.root)
+	}
+	return c.Valid()
+}
+
+func (c *Cursor[K, V]) Last() bool {
+	c.node = c.tree.sentinel
+	c.mod = c.tree.mod
+	if c.tree.root != c.tree.sentinel {
+		c.node = c.tree.maximum(c.tree.root)
+	}
+	return c.Valid()
+}
+
+// Seek moves the cursor to the first entry whose key is not less than key.
+func (c *Cursor[K, V]) Seek(key K) bool {
+	c.node = c.tree.lowerBoundNode(key)
+	c.mod = c.tree.mod
+	return c.Valid()
+}
+
+func (c *Cursor[K, V]) Next() bool {
+	if !c.Valid() {
+		return false
+	}
+	c.tree.checkMod(c.mod)
+	c.node = c.tree.successor(c.tree.live(c.node))
+	return c.Valid()
+}
+
+func (c *Cursor[K, V]) Prev() bool {
+	if !c.Valid() {
+		return false
+	}
+	c.tree.checkMod(c.mod)
+	c.node = c.tree.predecessor(c.tree.live(c.node))
+	return c.Valid()
+}
+
+func (c *Cursor[K, V]) Key() K {
+	return c.tree.live(c.node).key
+}
+
+func (c *Cursor[K, V]) Value() V {
+	return c.tree.live(c.node).value
+}
+
+func (c *Cursor[K, V]) SetValue(value V) {
+	if !c.Valid() {
+		panic("rbtree: SetValue on a cursor that is not positioned")
+	}
+	c.tree.checkMod(c.mod)
+	c.node = c.tree.mutable(c.tree.live(c.node))
+	c.node.value = value
+	c.tree.augmentPath(c.node)
+}
+
+// Delete removes the current entry and moves the cursor to the entry that
+// followed it.
+func (c *Cursor[K, V]) Delete() {
+	if !c.Valid() {
+		panic("rbtree: Delete on a cursor that is not positioned")
+	}
+	r := c.tree
+	r.checkMod(c.mod)
+	node := r.live(c.node)
+	next := r.successor(node)
+	r.deleteNode(node)
+	c.node = r.live(next)
+	c.mod = r.mod
+}
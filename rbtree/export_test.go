@@ -0,0 +1,81 @@
+package rbtree
+
+import "fmt"
+
+// Check verifies the red-black properties of r and the bookkeeping the
+// map keeps on top of them, and returns the first violation it finds.
+func Check[K any, V any](r *RBTreeMap[K, V]) error {
+	return r.check()
+}
+
+func (r *RBTreeMap[K, V]) check() error {
+	if r.sentinel.color != BLACK {
+		return fmt.Errorf("the sentinel is red")
+	}
+	if r.root == r.sentinel {
+		if r.size != 0 {
+			return fmt.Errorf("empty tree has size %d", r.size)
+		}
+		return nil
+	}
+	if r.root.color != BLACK {
+		return fmt.Errorf("root %v is red", r.root.key)
+	}
+	if r.root.parent != r.sentinel {
+		return fmt.Errorf("root %v has a parent", r.root.key)
+	}
+	if r.root.size != r.size {
+		return fmt.Errorf("root %v has size %d, but the map has %d entries", r.root.key, r.root.size, r.size)
+	}
+	_, err := r.checkNode(r.root, nil)
+	return err
+}
+
+// checkNode checks the subtree of node and returns its black height.
+// prev is the largest key seen so far in key order, or nil.
+func (r *RBTreeMap[K, V]) checkNode(node *Node[K, V], prev *K) (int, error) {
+	if node == r.sentinel {
+		return 1, nil
+	}
+	for _, child := range []*Node[K, V]{node.left, node.right} {
+		if child == r.sentinel {
+			continue
+		}
+		if child.parent != node {
+			return 0, fmt.Errorf("node %v does not point back at its parent %v", child.key, node.key)
+		}
+		if node.color == RED && child.color == RED {
+			return 0, fmt.Errorf("red node %v has a red child %v", node.key, child.key)
+		}
+	}
+	if size := node.left.size + node.right.size + 1; node.size != size {
+		return 0, fmt.Errorf("node %v has size %d, but its subtree holds %d", node.key, node.size, size)
+	}
+
+	leftHeight, err := r.checkNode(node.left, prev)
+	if err != nil {
+		return 0, err
+	}
+	if node.left != r.sentinel {
+		prev = &r.maximum(node.left).key
+	}
+	if prev != nil && r.compare(*prev, node.key) >= 0 {
+		return 0, fmt.Errorf("key %v follows key %v", node.key, *prev)
+	}
+	rightHeight, err := r.checkNode(node.right, &node.key)
+	if err != nil {
+		return 0, err
+	}
+	if leftHeight != rightHeight {
+		return 0, fmt.Errorf("node %v has black heights %d and %d below it", node.key, leftHeight, rightHeight)
+	}
+	if node.color == BLACK {
+		leftHeight++
+	}
+	return leftHeight, nil
+}
+
+// IntervalTreeMap returns the map inside t.
+func IntervalTreeMap[K any, V any](t *IntervalTree[K, V]) *RBTreeMap[Interval[K], intervalValue[K, V]] {
+	return t.tree
+}
@@ -0,0 +1,110 @@
+package rbtree
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+var (
+	ErrNotSorted    = errors.New("rbtree: keys are not in ascending order")
+	ErrDuplicateKey = errors.New("rbtree: duplicate key")
+)
+
+// FromSorted builds a map from keys in strictly ascending order and their
+// values in O(n). It returns an error wrapping ErrNotSorted or
+// ErrDuplicateKey if the keys are out of order or repeated.
+func FromSorted[K cmp.Ordered, V any](keys []K, values []V) (*RBTreeMap[K, V], error) {
+	r, err := FromSortedWithComparator(cmp.Compare[K], keys, values)
+	if r != nil {
+		r.naturalOrder = true
+	}
+	return r, err
+}
+
+func FromSortedWithComparator[K any, V any](compare func(a, b K) int, keys []K, values []V) (*RBTreeMap[K, V], error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("rbtree: got %d keys but %d values", len(keys), len(values))
+	}
+	r := NewWithComparator[K, V](compare)
+	nodes := make([]*Node[K, V], len(keys))
+	for i, key := range keys {
+		if i > 0 {
+			if err := r.checkSorted(keys[i-1], key, i); err != nil {
+				return nil, err
+			}
+		}
+		nodes[i] = &Node[K, V]{key: key, value: values[i], gen: r.gen}
+	}
+	r.buildSorted(nodes)
+	return r, nil
+}
+
+// FromSortedSeq is like FromSorted but reads the entries from seq.
+func FromSortedSeq[K cmp.Ordered, V any](seq iter.Seq2[K, V]) (*RBTreeMap[K, V], error) {
+	r, err := FromSortedSeqWithComparator(cmp.Compare[K], seq)
+	if r != nil {
+		r.naturalOrder = true
+	}
+	return r, err
+}
+
+func FromSortedSeqWithComparator[K any, V any](compare func(a, b K) int, seq iter.Seq2[K, V]) (*RBTreeMap[K, V], error) {
+	r := NewWithComparator[K, V](compare)
+	nodes := make([]*Node[K, V], 0)
+	var err error
+	for key, value := range seq {
+		if len(nodes) > 0 {
+			if err = r.checkSorted(nodes[len(nodes)-1].key, key, len(nodes)); err != nil {
+				break
+			}
+		}
+		nodes = append(nodes, &Node[K, V]{key: key, value: value, gen: r.gen})
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.buildSorted(nodes)
+	return r, nil
+}
+
+func (r *RBTreeMap[K, V]) checkSorted(prev, key K, i int) error {
+	c := r.compare(prev, key)
+	if c == 0 {
+		return fmt.Errorf("%w at index %d: %v", ErrDuplicateKey, i, key)
+	}
+	if c > 0 {
+		return fmt.Errorf("%w: key at index %d (%v) is less than the key before it (%v)", ErrNotSorted, i, key, prev)
+	}
+	return nil
+}
+
+// buildSorted links nodes, which are in key order, into a perfectly
+// balanced tree. Every level above the last one is full, so making the
+// nodes on a partial last level red and the rest black gives every path
+// the same number of black nodes.
+func (r *RBTreeMap[K, V]) buildSorted(nodes []*Node[K, V]) {
+	redDepth := bits.Len(uint(len(nodes)+1)) - 1
+	r.root = r.link(nodes, 0, redDepth, r.sentinel)
+	r.size = len(nodes)
+	r.mod++
+}
+
+func (r *RBTreeMap[K, V]) link(nodes []*Node[K, V], depth, redDepth int, parent *Node[K, V]) *Node[K, V] {
+	if len(nodes) == 0 {
+		return r.sentinel
+	}
+	mid := len(nodes) / 2
+	node := nodes[mid]
+	node.parent = parent
+	node.size = len(nodes)
+	if depth == redDepth {
+		node.color = RED
+	}
+	node.left = r.link(nodes[:mid], depth+1, redDepth, node)
+	node.right = r.link(nodes[mid+1:], depth+1, redDepth, node)
+	r.augmentNode(node)
+	return node
+}
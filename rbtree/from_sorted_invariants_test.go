@@ -0,0 +1,47 @@
+package rbtree_test
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFromSortedInvariants(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running FromSorted invariant test with seed: %d", seed)
+
+	// Every size up to a few levels, so each shape of a partial last
+	// level is built at least once.
+	for n := 0; n <= 1100; n++ {
+		keys := make([]int, n)
+		values := make([]int, n)
+		for i := range keys {
+			keys[i] = 2 * i
+			values[i] = i
+		}
+
+		tree, err := rbtree.FromSorted(keys, values)
+		if err != nil {
+			t.Fatalf("FromSorted with %d keys: %v", n, err)
+		}
+		mustCheck(t, tree, "FromSorted")
+
+		seqTree, err := rbtree.FromSortedSeq(tree.InOrder())
+		if err != nil {
+			t.Fatalf("FromSortedSeq with %d keys: %v", n, err)
+		}
+		mustCheck(t, seqTree, "FromSortedSeq")
+		if !slices.Equal(keys, collectKeys(seqTree)) {
+			t.Fatalf("FromSortedSeq with %d keys changed the content", n)
+		}
+
+		for i := 0; i < 20; i++ {
+			tree.Insert(rng.Intn(2*n+2), 0)
+			tree.Remove(rng.Intn(2*n + 2))
+		}
+		mustCheck(t, tree, "updates after FromSorted")
+	}
+}
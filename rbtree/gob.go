@@ -0,0 +1,67 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+var ErrNoComparator = errors.New("rbtree: map was encoded with a custom comparator")
+
+const gobVersion = 1
+
+// gobMap is the wire form of an RBTreeMap. Keys holds the keys in tree
+// order and Values the matching values.
+type gobMap[K any, V any] struct {
+	Version      int
+	NaturalOrder bool
+	Keys         []K
+	Values       []V
+}
+
+func (r *RBTreeMap[K, V]) GobEncode() ([]byte, error) {
+	m := gobMap[K, V]{
+		Version:      gobVersion,
+		NaturalOrder: r.naturalOrder,
+		Keys:         make([]K, 0, r.size),
+		Values:       make([]V, 0, r.size),
+	}
+	for k, v := range r.InOrder() {
+		m.Keys = append(m.Keys, k)
+		m.Values = append(m.Values, v)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the contents of the map with the encoded entries. A
+// map encoded with a custom comparator can only be decoded into a map that
+// already has one, such as a struct field set with NewWithComparator before
+// decoding; decoding it into a zero map returns ErrNoComparator. A zero
+// map whose key type is a named type, such as `type UserID int`, compares
+// its keys through reflection, which is slower than the comparator New
+// uses. Create it with New before decoding to avoid that.
+func (r *RBTreeMap[K, V]) GobDecode(data []byte) error {
+	var m gobMap[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
+		return err
+	}
+	if m.Version != gobVersion {
+		return fmt.Errorf("rbtree: unsupported gob version %d", m.Version)
+	}
+	if len(m.Keys) != len(m.Values) {
+		return fmt.Errorf("rbtree: gob data has %d keys but %d values", len(m.Keys), len(m.Values))
+	}
+	if r.compare == nil && !m.NaturalOrder {
+		return fmt.Errorf("%w, decode it into a map created with NewWithCompare or NewWithComparator", ErrNoComparator)
+	}
+	if err := r.initZero(); err != nil {
+		return err
+	}
+	r.load(m.Keys, m.Values)
+	return nil
+}
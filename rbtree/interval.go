@@ -0,0 +1,324 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Interval is the closed interval [Lo, Hi].
+type Interval[K any] struct {
+	Lo, Hi K
+}
+
+type intervalValue[K any, V any] struct {
+	value V
+	// max is the largest Hi in the node's subtree.
+	max K
+	// heap is the node whose interval fills this node's heap slot, or nil.
+	heap *Node[Interval[K], intervalValue[K, V]]
+	// inHeap is set while the node's own interval is in some heap slot.
+	inHeap bool
+}
+
+// IntervalTree maps closed intervals to values and finds the intervals
+// that overlap a range or contain a point. It is an RBTreeMap ordered by
+// Lo and then Hi whose nodes also keep the largest Hi of their subtree.
+//
+// The nodes double as a priority search tree. Each one has a heap slot
+// holding the interval with the largest Hi among those in its subtree that
+// no ancestor holds, and an interval that no slot holds stays with its own
+// node. Every interval below a node then ends no later than the one in the
+// node's slot, so Overlapping stops at the first slot that ends before the
+// range starts.
+//
+// The map is never split, joined or snapshotted, and it panics if it is,
+// so the heap slots are written in place.
+type IntervalTree[K any, V any] struct {
+	tree    *RBTreeMap[Interval[K], intervalValue[K, V]]
+	compare func(a, b K) int
+}
+
+func NewIntervalTree[K cmp.Ordered, V any]() *IntervalTree[K, V] {
+	return NewIntervalTreeWithComparator[K, V](cmp.Compare[K])
+}
+
+func NewIntervalTreeWithComparator[K any, V any](compare func(a, b K) int) *IntervalTree[K, V] {
+	t := &IntervalTree[K, V]{compare: compare}
+	t.tree = NewWithComparator[Interval[K], intervalValue[K, V]](func(a, b Interval[K]) int {
+		if c := compare(a.Lo, b.Lo); c != 0 {
+			return c
+		}
+		return compare(a.Hi, b.Hi)
+	})
+	t.tree.augment = t.updateMax
+	t.tree.rotated = t.rotateHeap
+	return t
+}
+
+func (t *IntervalTree[K, V]) updateMax(node *Node[Interval[K], intervalValue[K, V]]) {
+	m := node.key.Hi
+	if node.left != t.tree.sentinel && t.compare(node.left.value.max, m) > 0 {
+		m = node.left.value.max
+	}
+	if node.right != t.tree.sentinel && t.compare(node.right.value.max, m) > 0 {
+		m = node.right.value.max
+	}
+	node.value.max = m
+}
+
+// endsLater reports whether a ends after b.
+func (t *IntervalTree[K, V]) endsLater(a, b *Node[Interval[K], intervalValue[K, V]]) bool {
+	return t.compare(a.key.Hi, b.key.Hi) > 0
+}
+
+// siftIn adds the interval of node, which is in the tree but in no heap
+// slot, to the heap in O(log n). It walks down from the root towards node,
+// swapping it into the first slot that holds an interval ending earlier
+// and carrying that interval on, until the carried interval reaches an
+// empty slot or its own node.
+func (t *IntervalTree[K, V]) siftIn(node *Node[Interval[K], intervalValue[K, V]]) {
+	r := t.tree
+	current := r.root
+	for {
+		slot := current.value.heap
+		if slot == nil {
+			current.value.heap = node
+			node.value.inHeap = true
+			return
+		}
+		if t.endsLater(node, slot) {
+			current.value.heap = node
+			node.value.inHeap = true
+			node = slot
+		}
+		c := r.compare(node.key, current.key)
+		if c == 0 {
+			node.value.inHeap = false
+			return
+		}
+		if c < 0 {
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+}
+
+// refill fills the emptied heap slot of node in O(log n). It takes the
+// latest ending of the intervals in the children's slots and node's own
+// interval, if no slot holds it, then refills the slot it took from.
+func (t *IntervalTree[K, V]) refill(node *Node[Interval[K], intervalValue[K, V]]) {
+	r := t.tree
+	for {
+		var best, from *Node[Interval[K], intervalValue[K, V]]
+		if !node.value.inHeap {
+			best = node
+		}
+		for _, child := range [2]*Node[Interval[K], intervalValue[K, V]]{node.left, node.right} {
+			if child != r.sentinel && child.value.heap != nil && (best == nil || t.endsLater(child.value.heap, best)) {
+				best, from = child.value.heap, child
+			}
+		}
+		node.value.heap = best
+		if best == nil {
+			return
+		}
+		if from == nil {
+			best.value.inHeap = true
+			return
+		}
+		from.value.heap = nil
+		node = from
+	}
+}
+
+// holder returns the node whose heap slot holds the interval of node, which
+// is always on the path from the root to node.
+func (t *IntervalTree[K, V]) holder(node *Node[Interval[K], intervalValue[K, V]]) *Node[Interval[K], intervalValue[K, V]] {
+	r := t.tree
+	current := r.root
+	for current.value.heap != node {
+		if r.compare(node.key, current.key) < 0 {
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+	return current
+}
+
+// takeOut removes the interval of node from the heap. Its inHeap flag
+// stays set, which keeps refill from putting it back before it is sifted
+// in again.
+func (t *IntervalTree[K, V]) takeOut(node *Node[Interval[K], intervalValue[K, V]]) {
+	if !node.value.inHeap {
+		node.value.inHeap = true
+		return
+	}
+	h := t.holder(node)
+	h.value.heap = nil
+	t.refill(h)
+}
+
+// rotateHeap restores the heap after a rotation. The subtree keeps its
+// latest ending interval at the top, so parent takes over the slot of
+// child. The interval parent held is sifted in again once child's slot has
+// been refilled from its new subtree.
+func (t *IntervalTree[K, V]) rotateHeap(parent, child *Node[Interval[K], intervalValue[K, V]]) {
+	displaced := parent.value.heap
+	parent.value.heap = child.value.heap
+	child.value.heap = nil
+	t.refill(child)
+	if displaced != nil {
+		t.siftIn(displaced)
+	}
+}
+
+func (t *IntervalTree[K, V]) Size() int {
+	return t.tree.Size()
+}
+
+// Insert adds the interval [lo, hi] or replaces its value. It panics if
+// lo is greater than hi.
+func (t *IntervalTree[K, V]) Insert(lo, hi K, value V) {
+	if t.compare(lo, hi) > 0 {
+		panic("rbtree: interval with Lo greater than Hi")
+	}
+	r := t.tree
+	interval := Interval[K]{lo, hi}
+	if node := r.search(interval); node != r.sentinel {
+		node.value.value = value
+		return
+	}
+	node := r.attach(interval, intervalValue[K, V]{value: value, inHeap: true})
+	t.siftIn(node)
+	r.fixInsert(node)
+}
+
+func (t *IntervalTree[K, V]) Get(lo, hi K) (V, bool) {
+	v, ok := t.tree.Get(Interval[K]{lo, hi})
+	return v.value, ok
+}
+
+func (t *IntervalTree[K, V]) Remove(lo, hi K) {
+	r := t.tree
+	z := r.search(Interval[K]{lo, hi})
+	if z == r.sentinel {
+		return
+	}
+	t.takeOut(z)
+
+	// unlink moves the successor y of a node with two children into its
+	// place, and y's right subtree into the place of y. y takes over the
+	// slot of z, whose interval range it now covers, while the interval of
+	// y and the one in its old slot are sifted in again from the top.
+	var y, yHeap *Node[Interval[K], intervalValue[K, V]]
+	if z.left != r.sentinel && z.right != r.sentinel {
+		y = r.minimum(z.right)
+		t.takeOut(y)
+		yHeap = y.value.heap
+	}
+	zHeap := z.value.heap
+	z.value.heap = nil
+
+	x, xParent, black := r.unlink(z)
+	if y == nil {
+		if zHeap != nil {
+			t.siftIn(zHeap)
+		}
+	} else {
+		y.value.heap = zHeap
+		if yHeap != nil {
+			t.siftIn(yHeap)
+		}
+		t.siftIn(y)
+	}
+	if black {
+		r.fixDelete(x, xParent)
+	}
+}
+
+// InOrder iterates over the intervals ordered by Lo and then Hi.
+func (t *IntervalTree[K, V]) InOrder() iter.Seq2[Interval[K], V] {
+	return func(yield func(interval Interval[K], value V) bool) {
+		for interval, v := range t.tree.InOrder() {
+			if !yield(interval, v.value) {
+				return
+			}
+		}
+	}
+}
+
+// Overlapping iterates over the intervals that share at least one point
+// with [lo, hi], in no particular order. Reporting k intervals takes
+// O(log n + k) time: the walk stops at every heap slot that ends before lo,
+// and every slot it passes holds a match except those on the path that
+// separates the intervals starting up to hi from the rest.
+func (t *IntervalTree[K, V]) Overlapping(lo, hi K) iter.Seq2[Interval[K], V] {
+	return func(yield func(interval Interval[K], value V) bool) {
+		r := t.tree
+		mod := r.mod
+		stack := []*Node[Interval[K], intervalValue[K, V]]{r.root}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if node == r.sentinel {
+				continue
+			}
+			slot := node.value.heap
+			if slot == nil || t.compare(slot.key.Hi, lo) < 0 {
+				continue
+			}
+			if t.compare(slot.key.Lo, hi) <= 0 {
+				if !yield(slot.key, slot.value.value) {
+					return
+				}
+				r.checkMod(mod)
+			}
+			if !node.value.inHeap && t.overlaps(node.key, lo, hi) {
+				if !yield(node.key, node.value.value) {
+					return
+				}
+				r.checkMod(mod)
+			}
+			stack = append(stack, node.left)
+			if t.compare(node.key.Lo, hi) <= 0 {
+				stack = append(stack, node.right)
+			}
+		}
+	}
+}
+
+func (t *IntervalTree[K, V]) overlaps(interval Interval[K], lo, hi K) bool {
+	return t.compare(interval.Lo, hi) <= 0 && t.compare(interval.Hi, lo) >= 0
+}
+
+// Containing iterates over the intervals that contain point.
+func (t *IntervalTree[K, V]) Containing(point K) iter.Seq2[Interval[K], V] {
+	return t.Overlapping(point, point)
+}
+
+// AnyOverlapping reports whether some interval overlaps [lo, hi] in
+// O(log n).
+func (t *IntervalTree[K, V]) AnyOverlapping(lo, hi K) bool {
+	r := t.tree
+	node := r.root
+	for node != r.sentinel {
+		if t.overlaps(node.key, lo, hi) {
+			return true
+		}
+		// If the left subtree reaches lo but has no match, every interval in
+		// it starts after hi, and so does every interval to the right.
+		if node.left != r.sentinel && t.compare(node.left.value.max, lo) >= 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return false
+}
+
+// AnyContaining reports whether some interval contains point in O(log n).
+func (t *IntervalTree[K, V]) AnyContaining(point K) bool {
+	return t.AnyOverlapping(point, point)
+}
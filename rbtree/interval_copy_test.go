@@ -0,0 +1,43 @@
+package rbtree_test
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"testing"
+)
+
+func TestIntervalTreeMapCannotBeCopied(t *testing.T) {
+	intervals := rbtree.NewIntervalTree[int, string]()
+	for i := 0; i < 100; i++ {
+		intervals.Insert(i, i+10, "")
+	}
+	m := rbtree.IntervalTreeMap(intervals)
+	other := rbtree.IntervalTreeMap(rbtree.NewIntervalTree[int, string]())
+
+	ops := map[string]func(){
+		"Snapshot":       func() { m.Snapshot() },
+		"Split":          func() { m.Split(rbtree.Interval[int]{Lo: 50, Hi: 50}) },
+		"Join":           func() { rbtree.Join(other, m) },
+		"Union":          func() { m.Union(other, nil) },
+		"UnionInto":      func() { rbtree.UnionInto(other, m, nil) },
+		"DifferenceInto": func() { rbtree.DifferenceInto(m, other) },
+		"UnmarshalJSON":  func() { m.UnmarshalJSON([]byte(`[]`)) },
+	}
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected %s on an interval tree's map to panic", name)
+				}
+			}()
+			op()
+		})
+	}
+
+	count := 0
+	for range intervals.Overlapping(0, 200) {
+		count++
+	}
+	if count != 100 {
+		t.Errorf("Expected the interval tree to keep its 100 intervals, but found %d", count)
+	}
+}
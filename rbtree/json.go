@@ -0,0 +1,197 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// MarshalJSON encodes the map with its entries in key order. Maps whose
+// keys are strings or implement encoding.TextMarshaler become a JSON
+// object; any other map becomes an array of [key, value] pairs.
+func (r *RBTreeMap[K, V]) MarshalJSON() ([]byte, error) {
+	asObject := textKeys[K]()
+	var buf bytes.Buffer
+	if asObject {
+		buf.WriteByte('{')
+	} else {
+		buf.WriteByte('[')
+	}
+
+	first := true
+	for k, v := range r.InOrder() {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		var key []byte
+		var err error
+		if asObject {
+			key, err = marshalTextKey(k)
+		} else {
+			key, err = json.Marshal(k)
+		}
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if asObject {
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		} else {
+			buf.WriteByte('[')
+			buf.Write(key)
+			buf.WriteByte(',')
+			buf.Write(value)
+			buf.WriteByte(']')
+		}
+	}
+
+	if asObject {
+		buf.WriteByte('}')
+	} else {
+		buf.WriteByte(']')
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON replaces the contents of the map with the entries in data,
+// which may be in either form written by MarshalJSON. A key that appears
+// more than once keeps its last value. Entries that are already in key
+// order are loaded in O(n).
+func (r *RBTreeMap[K, V]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	if err := r.initZero(); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	start, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	var keys []K
+	var values []V
+	switch start {
+	case json.Delim('{'):
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, err := unmarshalTextKey[K](token.(string))
+			if err != nil {
+				return err
+			}
+			var value V
+			if err := dec.Decode(&value); err != nil {
+				return err
+			}
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+	case json.Delim('['):
+		for dec.More() {
+			var pair []json.RawMessage
+			if err := dec.Decode(&pair); err != nil {
+				return err
+			}
+			if len(pair) != 2 {
+				return fmt.Errorf("rbtree: expected a [key, value] pair, but got %d elements", len(pair))
+			}
+			var key K
+			if err := json.Unmarshal(pair[0], &key); err != nil {
+				return err
+			}
+			var value V
+			if err := json.Unmarshal(pair[1], &value); err != nil {
+				return err
+			}
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+	default:
+		return fmt.Errorf("rbtree: cannot unmarshal %v into a map, expected an object or an array", start)
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	r.load(keys, values)
+	return nil
+}
+
+// load replaces the contents of the map. It builds the tree directly when
+// the keys are already strictly ascending and inserts them otherwise.
+func (r *RBTreeMap[K, V]) load(keys []K, values []V) {
+	r.checkCopyable()
+	sorted := true
+	for i := 1; i < len(keys) && sorted; i++ {
+		sorted = r.compare(keys[i-1], keys[i]) < 0
+	}
+
+	if sorted {
+		r.gen = nextGen()
+		nodes := make([]*Node[K, V], len(keys))
+		for i, key := range keys {
+			nodes[i] = &Node[K, V]{key: key, value: values[i], gen: r.gen}
+		}
+		r.buildSorted(nodes)
+		return
+	}
+
+	fresh := r.adopt(r.sentinel)
+	for i, key := range keys {
+		fresh.Insert(key, values[i])
+	}
+	r.root = fresh.root
+	r.size = fresh.size
+	r.gen = fresh.gen
+	r.mod++
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+func textKeys[K any]() bool {
+	t := reflect.TypeFor[K]()
+	return t.Kind() == reflect.String || t.Implements(textMarshalerType)
+}
+
+// marshalTextKey follows encoding/json: keys of a string kind are used as
+// they are, before encoding.TextMarshaler is considered.
+func marshalTextKey[K any](key K) ([]byte, error) {
+	v := reflect.ValueOf(key)
+	if v.Kind() == reflect.String {
+		return json.Marshal(v.String())
+	}
+	text, err := any(key).(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+func unmarshalTextKey[K any](text string) (K, error) {
+	var key K
+	v := reflect.ValueOf(&key).Elem()
+	if v.Kind() == reflect.String {
+		v.SetString(text)
+		return key, nil
+	}
+	if u, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := u.UnmarshalText([]byte(text))
+		return key, err
+	}
+	return key, fmt.Errorf("rbtree: cannot use object key %q as %T, expected an array of [key, value] pairs", text, key)
+}
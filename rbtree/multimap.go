@@ -0,0 +1,122 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+	"math"
+)
+
+// multiKey makes every entry of a multimap unique. Entries with equal keys
+// are ordered by seq, which grows with every insert.
+type multiKey[K any] struct {
+	key K
+	seq uint64
+}
+
+// RBTreeMultiMap is an ordered map that can hold several values for the
+// same key. Values with equal keys are kept in insertion order.
+type RBTreeMultiMap[K any, V any] struct {
+	tree    *RBTreeMap[multiKey[K], V]
+	compare func(a, b K) int
+	seq     uint64
+}
+
+func NewMultiMap[K cmp.Ordered, V any]() *RBTreeMultiMap[K, V] {
+	return NewMultiMapWithComparator[K, V](cmp.Compare[K])
+}
+
+func NewMultiMapWithComparator[K any, V any](compare func(a, b K) int) *RBTreeMultiMap[K, V] {
+	return &RBTreeMultiMap[K, V]{
+		tree: NewWithComparator[multiKey[K], V](func(a, b multiKey[K]) int {
+			if c := compare(a.key, b.key); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.seq, b.seq)
+		}),
+		compare: compare,
+	}
+}
+
+func (m *RBTreeMultiMap[K, V]) Size() int {
+	return m.tree.Size()
+}
+
+// Insert adds value under key, after any values already stored for it.
+func (m *RBTreeMultiMap[K, V]) Insert(key K, value V) {
+	m.seq++
+	m.tree.Insert(multiKey[K]{key, m.seq}, value)
+}
+
+func (m *RBTreeMultiMap[K, V]) ContainsKey(key K) bool {
+	node := m.tree.lowerBoundNode(multiKey[K]{key, 0})
+	return node != m.tree.sentinel && m.compare(node.key.key, key) == 0
+}
+
+// Count returns the number of values stored for key in O(log n).
+func (m *RBTreeMultiMap[K, V]) Count(key K) int {
+	return m.tree.CountRange(multiKey[K]{key, 0}, multiKey[K]{key, math.MaxUint64}, IncludeHi)
+}
+
+// GetAll iterates over the values stored for key in insertion order.
+func (m *RBTreeMultiMap[K, V]) GetAll(key K) iter.Seq[V] {
+	return func(yield func(value V) bool) {
+		for _, v := range m.EqualRange(key) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// EqualRange iterates over the entries whose keys are equivalent to key,
+// in insertion order. The keys are yielded as they were inserted, which
+// matters when the comparator treats different keys as equal.
+func (m *RBTreeMultiMap[K, V]) EqualRange(key K) iter.Seq2[K, V] {
+	return m.keys(func() iter.Seq2[multiKey[K], V] {
+		return m.tree.Range(multiKey[K]{key, 0}, multiKey[K]{key, math.MaxUint64}, IncludeHi)
+	})
+}
+
+// InOrder iterates over all entries ordered by key and then by insertion.
+func (m *RBTreeMultiMap[K, V]) InOrder() iter.Seq2[K, V] {
+	return m.keys(func() iter.Seq2[multiKey[K], V] {
+		return m.tree.InOrder()
+	})
+}
+
+// keys strips the sequence numbers from the keys. It takes a function
+// rather than an iterator because RemoveAll replaces m.tree, and the
+// iteration has to walk the tree that is current when it starts.
+func (m *RBTreeMultiMap[K, V]) keys(seq func() iter.Seq2[multiKey[K], V]) iter.Seq2[K, V] {
+	return func(yield func(key K, value V) bool) {
+		for k, v := range seq() {
+			if !yield(k.key, v) {
+				return
+			}
+		}
+	}
+}
+
+// RemoveOne removes the oldest value stored for key and reports whether
+// there was one.
+func (m *RBTreeMultiMap[K, V]) RemoveOne(key K) bool {
+	node := m.tree.lowerBoundNode(multiKey[K]{key, 0})
+	if node == m.tree.sentinel || m.compare(node.key.key, key) != 0 {
+		return false
+	}
+	m.tree.deleteNode(node)
+	return true
+}
+
+// RemoveAll removes every value stored for key and returns how many there
+// were. It cuts the values out with Split and Join, so it takes O(log n)
+// however many values the key has.
+func (m *RBTreeMultiMap[K, V]) RemoveAll(key K) int {
+	if !m.ContainsKey(key) {
+		return 0
+	}
+	left, rest := m.tree.Split(multiKey[K]{key, 0})
+	equal, right := rest.Split(multiKey[K]{key, math.MaxUint64})
+	m.tree = Join(left, right)
+	return equal.Size()
+}
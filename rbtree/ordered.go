@@ -0,0 +1,89 @@
+package rbtree
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+)
+
+// orderedCompare returns a comparator matching cmp.Compare for key types
+// whose underlying type is ordered, or nil for any other key type. The
+// decoders use it to fill in a zero RBTreeMap, which has no comparator.
+func orderedCompare[K any]() func(a, b K) int {
+	var compare any
+	switch any(*new(K)).(type) {
+	case int:
+		compare = cmp.Compare[int]
+	case int8:
+		compare = cmp.Compare[int8]
+	case int16:
+		compare = cmp.Compare[int16]
+	case int32:
+		compare = cmp.Compare[int32]
+	case int64:
+		compare = cmp.Compare[int64]
+	case uint:
+		compare = cmp.Compare[uint]
+	case uint8:
+		compare = cmp.Compare[uint8]
+	case uint16:
+		compare = cmp.Compare[uint16]
+	case uint32:
+		compare = cmp.Compare[uint32]
+	case uint64:
+		compare = cmp.Compare[uint64]
+	case uintptr:
+		compare = cmp.Compare[uintptr]
+	case float32:
+		compare = cmp.Compare[float32]
+	case float64:
+		compare = cmp.Compare[float64]
+	case string:
+		compare = cmp.Compare[string]
+	}
+	if compare != nil {
+		return compare.(func(a, b K) int)
+	}
+
+	// Named types such as `type UserID int` fall back to reflection. Every
+	// comparison then calls reflect.ValueOf on both keys, which adds to the
+	// cost of each later Insert, Get and Remove on the decoded map compared
+	// with a map created with New.
+	switch reflect.TypeFor[K]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b K) int {
+			return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b K) int {
+			return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(a, b K) int {
+			return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float())
+		}
+	case reflect.String:
+		return func(a, b K) int {
+			return cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+		}
+	}
+	return nil
+}
+
+// initZero makes a zero RBTreeMap usable before it is decoded into. It
+// fails when the key type has no natural order.
+func (r *RBTreeMap[K, V]) initZero() error {
+	if r.compare != nil {
+		return nil
+	}
+	compare := orderedCompare[K]()
+	if compare == nil {
+		return fmt.Errorf("rbtree: keys of type %v have no natural order, create the map with NewWithComparator before decoding", reflect.TypeFor[K]())
+	}
+	r.sentinel = sentinelFor[K, V]()
+	r.root = r.sentinel
+	r.gen = nextGen()
+	r.compare = compare
+	r.naturalOrder = true
+	return nil
+}
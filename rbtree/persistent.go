@@ -0,0 +1,282 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+type persistentNode[K any, V any] struct {
+	key   K
+	value V
+	color color
+	left  *persistentNode[K, V]
+	right *persistentNode[K, V]
+}
+
+// PersistentMap is an immutable ordered map. Insert and Remove return a new
+// version that shares every untouched subtree with the receiver, so older
+// versions stay valid and can be read concurrently.
+//
+// The tree is a left-leaning red-black tree without parent pointers, which
+// keeps the number of nodes copied by an update at O(log n).
+type PersistentMap[K any, V any] struct {
+	root    *persistentNode[K, V]
+	size    int
+	compare func(a, b K) int
+}
+
+func NewPersistent[K cmp.Ordered, V any]() *PersistentMap[K, V] {
+	return NewPersistentWithComparator[K, V](cmp.Compare[K])
+}
+
+func NewPersistentWithComparator[K any, V any](compare func(a, b K) int) *PersistentMap[K, V] {
+	return &PersistentMap[K, V]{compare: compare}
+}
+
+func (p *PersistentMap[K, V]) Size() int {
+	return p.size
+}
+
+func (p *PersistentMap[K, V]) search(key K) *persistentNode[K, V] {
+	current := p.root
+	for current != nil {
+		c := p.compare(key, current.key)
+		if c == 0 {
+			return current
+		}
+		if c < 0 {
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+	return nil
+}
+
+func (p *PersistentMap[K, V]) Get(key K) (V, bool) {
+	node := p.search(key)
+	if node != nil {
+		return node.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (p *PersistentMap[K, V]) ContainsKey(key K) bool {
+	return p.search(key) != nil
+}
+
+func (p *PersistentMap[K, V]) Insert(key K, value V) *PersistentMap[K, V] {
+	root, added := p.insert(p.root, key, value)
+	root.color = BLACK
+	next := &PersistentMap[K, V]{root: root, size: p.size, compare: p.compare}
+	if added {
+		next.size++
+	}
+	return next
+}
+
+func (p *PersistentMap[K, V]) Remove(key K) *PersistentMap[K, V] {
+	if p.search(key) == nil {
+		return p
+	}
+	root := p.root.clone()
+	if !isRedPersistent(root.left) && !isRedPersistent(root.right) {
+		root.color = RED
+	}
+	root = p.remove(root, key)
+	if root != nil {
+		root.color = BLACK
+	}
+	return &PersistentMap[K, V]{root: root, size: p.size - 1, compare: p.compare}
+}
+
+func (p *PersistentMap[K, V]) InOrder() iter.Seq2[K, V] {
+	return func(yield func(key K, value V) bool) {
+		stack := make([]*persistentNode[K, V], 0)
+		current := p.root
+		for {
+			for current != nil {
+				stack = append(stack, current)
+				current = current.left
+			}
+			if len(stack) == 0 {
+				return
+			}
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(node.key, node.value) {
+				return
+			}
+			current = node.right
+		}
+	}
+}
+
+func (p *PersistentMap[K, V]) LowerBound(key K) (K, V, bool) {
+	var result *persistentNode[K, V]
+	current := p.root
+
+	for current != nil {
+		if p.compare(current.key, key) >= 0 {
+			result = current
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+	return result.entry()
+}
+
+func (p *PersistentMap[K, V]) UpperBound(key K) (K, V, bool) {
+	var result *persistentNode[K, V]
+	current := p.root
+
+	for current != nil {
+		if p.compare(key, current.key) < 0 {
+			result = current
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+	return result.entry()
+}
+
+func (n *persistentNode[K, V]) entry() (K, V, bool) {
+	if n != nil {
+		return n.key, n.value, true
+	}
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
+}
+
+func (n *persistentNode[K, V]) clone() *persistentNode[K, V] {
+	copied := *n
+	return &copied
+}
+
+func isRedPersistent[K any, V any](n *persistentNode[K, V]) bool {
+	return n != nil && n.color == RED
+}
+
+// The helpers below only modify nodes copied during the current update;
+// shared nodes are cloned before they are changed.
+
+func (p *PersistentMap[K, V]) insert(h *persistentNode[K, V], key K, value V) (*persistentNode[K, V], bool) {
+	if h == nil {
+		return &persistentNode[K, V]{key: key, value: value, color: RED}, true
+	}
+	h = h.clone()
+	added := false
+	c := p.compare(key, h.key)
+	if c < 0 {
+		h.left, added = p.insert(h.left, key, value)
+	} else if c > 0 {
+		h.right, added = p.insert(h.right, key, value)
+	} else {
+		h.value = value
+	}
+	return fixUpPersistent(h), added
+}
+
+func (p *PersistentMap[K, V]) remove(h *persistentNode[K, V], key K) *persistentNode[K, V] {
+	if p.compare(key, h.key) < 0 {
+		if !isRedPersistent(h.left) && !isRedPersistent(h.left.left) {
+			h = moveRedLeftPersistent(h)
+		}
+		h.left = p.remove(h.left.clone(), key)
+	} else {
+		if isRedPersistent(h.left) {
+			h = rotateRightPersistent(h)
+		}
+		if p.compare(key, h.key) == 0 && h.right == nil {
+			return nil
+		}
+		if !isRedPersistent(h.right) && !isRedPersistent(h.right.left) {
+			h = moveRedRightPersistent(h)
+		}
+		if p.compare(key, h.key) == 0 {
+			successor := h.right
+			for successor.left != nil {
+				successor = successor.left
+			}
+			h.key = successor.key
+			h.value = successor.value
+			h.right = removeMinPersistent(h.right.clone())
+		} else {
+			h.right = p.remove(h.right.clone(), key)
+		}
+	}
+	return fixUpPersistent(h)
+}
+
+func removeMinPersistent[K any, V any](h *persistentNode[K, V]) *persistentNode[K, V] {
+	if h.left == nil {
+		return nil
+	}
+	if !isRedPersistent(h.left) && !isRedPersistent(h.left.left) {
+		h = moveRedLeftPersistent(h)
+	}
+	h.left = removeMinPersistent(h.left.clone())
+	return fixUpPersistent(h)
+}
+
+func rotateLeftPersistent[K any, V any](h *persistentNode[K, V]) *persistentNode[K, V] {
+	x := h.right.clone()
+	h.right = x.left
+	x.left = h
+	x.color = h.color
+	h.color = RED
+	return x
+}
+
+func rotateRightPersistent[K any, V any](h *persistentNode[K, V]) *persistentNode[K, V] {
+	x := h.left.clone()
+	h.left = x.right
+	x.right = h
+	x.color = h.color
+	h.color = RED
+	return x
+}
+
+func flipColorsPersistent[K any, V any](h *persistentNode[K, V]) {
+	h.color = !h.color
+	h.left = h.left.clone()
+	h.left.color = !h.left.color
+	h.right = h.right.clone()
+	h.right.color = !h.right.color
+}
+
+func moveRedLeftPersistent[K any, V any](h *persistentNode[K, V]) *persistentNode[K, V] {
+	flipColorsPersistent(h)
+	if isRedPersistent(h.right.left) {
+		h.right = rotateRightPersistent(h.right)
+		h = rotateLeftPersistent(h)
+		flipColorsPersistent(h)
+	}
+	return h
+}
+
+func moveRedRightPersistent[K any, V any](h *persistentNode[K, V]) *persistentNode[K, V] {
+	flipColorsPersistent(h)
+	if isRedPersistent(h.left.left) {
+		h = rotateRightPersistent(h)
+		flipColorsPersistent(h)
+	}
+	return h
+}
+
+func fixUpPersistent[K any, V any](h *persistentNode[K, V]) *persistentNode[K, V] {
+	if isRedPersistent(h.right) && !isRedPersistent(h.left) {
+		h = rotateLeftPersistent(h)
+	}
+	if isRedPersistent(h.left) && isRedPersistent(h.left.left) {
+		h = rotateRightPersistent(h)
+	}
+	if isRedPersistent(h.left) && isRedPersistent(h.right) {
+		flipColorsPersistent(h)
+	}
+	return h
+}
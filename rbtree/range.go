@@ -0,0 +1,146 @@
+package rbtree
+
+import "iter"
+
+// RangeOption changes which ends of a range are part of it. By default a
+// range includes lo and excludes hi.
+type RangeOption uint8
+
+const (
+	ExcludeLo RangeOption = 1 << iota
+	IncludeHi
+)
+
+type bounds[K any] struct {
+	lo, hi       K
+	hasLo, hasHi bool
+	opts         RangeOption
+}
+
+func newBounds[K any](opts []RangeOption) bounds[K] {
+	var b bounds[K]
+	for _, opt := range opts {
+		b.opts |= opt
+	}
+	return b
+}
+
+// Range iterates over the entries with lo <= key < hi in ascending order.
+func (r *RBTreeMap[K, V]) Range(lo, hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	b := newBounds[K](opts)
+	b.lo, b.hasLo = lo, true
+	b.hi, b.hasHi = hi, true
+	return r.ascend(b)
+}
+
+// RangeFrom iterates over the entries with key >= lo in ascending order.
+func (r *RBTreeMap[K, V]) RangeFrom(lo K, opts ...RangeOption) iter.Seq2[K, V] {
+	b := newBounds[K](opts)
+	b.lo, b.hasLo = lo, true
+	return r.ascend(b)
+}
+
+// RangeTo iterates over the entries with key < hi in ascending order.
+func (r *RBTreeMap[K, V]) RangeTo(hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	b := newBounds[K](opts)
+	b.hi, b.hasHi = hi, true
+	return r.ascend(b)
+}
+
+// RangeBackward iterates over the same entries as Range in descending order.
+func (r *RBTreeMap[K, V]) RangeBackward(lo, hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	b := newBounds[K](opts)
+	b.lo, b.hasLo = lo, true
+	b.hi, b.hasHi = hi, true
+	return r.descend(b)
+}
+
+// RangeFromBackward iterates over the same entries as RangeFrom in descending order.
+func (r *RBTreeMap[K, V]) RangeFromBackward(lo K, opts ...RangeOption) iter.Seq2[K, V] {
+	b := newBounds[K](opts)
+	b.lo, b.hasLo = lo, true
+	return r.descend(b)
+}
+
+// RangeToBackward iterates over the same entries as RangeTo in descending order.
+func (r *RBTreeMap[K, V]) RangeToBackward(hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	b := newBounds[K](opts)
+	b.hi, b.hasHi = hi, true
+	return r.descend(b)
+}
+
+func (r *RBTreeMap[K, V]) aboveLo(b bounds[K], key K) bool {
+	if !b.hasLo {
+		return true
+	}
+	c := r.compare(key, b.lo)
+	return c > 0 || (c == 0 && b.opts&ExcludeLo == 0)
+}
+
+func (r *RBTreeMap[K, V]) belowHi(b bounds[K], key K) bool {
+	if !b.hasHi {
+		return true
+	}
+	c := r.compare(key, b.hi)
+	return c < 0 || (c == 0 && b.opts&IncludeHi != 0)
+}
+
+func (r *RBTreeMap[K, V]) ascend(b bounds[K]) iter.Seq2[K, V] {
+	return func(yield func(key K, value V) bool) {
+		mod := r.mod
+		stack := make([]*Node[K, V], 0)
+		current := r.root
+		for current != r.sentinel {
+			if r.aboveLo(b, current.key) {
+				stack = append(stack, current)
+				current = current.left
+			} else {
+				current = current.right
+			}
+		}
+		for len(stack) > 0 {
+			node := r.live(stack[len(stack)-1])
+			stack = stack[:len(stack)-1]
+			if !r.belowHi(b, node.key) {
+				return
+			}
+			if !yield(node.key, node.value) {
+				return
+			}
+			r.checkMod(mod)
+			for current = node.right; current != r.sentinel; current = current.left {
+				stack = append(stack, current)
+			}
+		}
+	}
+}
+
+func (r *RBTreeMap[K, V]) descend(b bounds[K]) iter.Seq2[K, V] {
+	return func(yield func(key K, value V) bool) {
+		mod := r.mod
+		stack := make([]*Node[K, V], 0)
+		current := r.root
+		for current != r.sentinel {
+			if r.belowHi(b, current.key) {
+				stack = append(stack, current)
+				current = current.right
+			} else {
+				current = current.left
+			}
+		}
+		for len(stack) > 0 {
+			node := r.live(stack[len(stack)-1])
+			stack = stack[:len(stack)-1]
+			if !r.aboveLo(b, node.key) {
+				return
+			}
+			if !yield(node.key, node.value) {
+				return
+			}
+			r.checkMod(mod)
+			for current = node.left; current != r.sentinel; current = current.right {
+				stack = append(stack, current)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package rbtree
+
+import "cmp"
+
+// Number is the set of value types a SumMap can add up.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// CountRange returns the number of keys in [lo, hi) in O(log n). The
+// options change which bounds are included, as in Range.
+func (r *RBTreeMap[K, V]) CountRange(lo, hi K, opts ...RangeOption) int {
+	b := newBounds[K](opts)
+	b.lo, b.hasLo = lo, true
+	b.hi, b.hasHi = hi, true
+	below := r.countWhile(func(key K) bool { return !r.aboveLo(b, key) })
+	upTo := r.countWhile(func(key K) bool { return r.belowHi(b, key) })
+	return max(upTo-below, 0)
+}
+
+// countWhile returns the number of keys for which pred holds, where pred
+// holds for every key up to some point in key order and for none after it.
+func (r *RBTreeMap[K, V]) countWhile(pred func(key K) bool) int {
+	count := 0
+	current := r.root
+	for current != r.sentinel {
+		if pred(current.key) {
+			count += current.left.size + 1
+			current = current.right
+		} else {
+			current = current.left
+		}
+	}
+	return count
+}
+
+// SumMap is an AggregateMap that keeps the sum of the values of every
+// subtree from the moment it is built, so SumRange only reads.
+type SumMap[K any, V Number] struct {
+	*AggregateMap[K, V, V]
+}
+
+func NewSumMap[K cmp.Ordered, V Number]() *SumMap[K, V] {
+	return NewSumMapWithComparator[K, V](cmp.Compare[K])
+}
+
+func NewSumMapWithComparator[K any, V Number](compare func(a, b K) int) *SumMap[K, V] {
+	return &SumMap[K, V]{NewWithMonoidAndComparator[K](compare, Monoid[V, V]{
+		Combine: func(a, b V) V { return a + b },
+	})}
+}
+
+// SumRange returns the sum of the values with keys in [lo, hi) in
+// O(log n). The options change which bounds are included, as in Range.
+// Only values inside the range are added, so float sums are as precise
+// as summing the range directly.
+func (m *SumMap[K, V]) SumRange(lo, hi K, opts ...RangeOption) V {
+	return m.Aggregate(lo, hi, opts...)
+}
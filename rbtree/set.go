@@ -0,0 +1,145 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// RBTreeSet is an ordered set of keys. It is an RBTreeMap with struct{}
+// values, which add nothing to the size of a node.
+type RBTreeSet[K any] struct {
+	tree *RBTreeMap[K, struct{}]
+}
+
+func NewSet[K cmp.Ordered]() *RBTreeSet[K] {
+	return &RBTreeSet[K]{tree: New[K, struct{}]()}
+}
+
+func NewSetWithComparator[K any](compare func(a, b K) int) *RBTreeSet[K] {
+	return &RBTreeSet[K]{tree: NewWithComparator[K, struct{}](compare)}
+}
+
+func (s *RBTreeSet[K]) Size() int {
+	return s.tree.Size()
+}
+
+// Add adds key to the set and reports whether it was not there before.
+func (s *RBTreeSet[K]) Add(key K) bool {
+	size := s.tree.Size()
+	s.tree.Insert(key, struct{}{})
+	return s.tree.Size() != size
+}
+
+// Delete removes key from the set and reports whether it was there.
+func (s *RBTreeSet[K]) Delete(key K) bool {
+	node := s.tree.search(key)
+	if node == s.tree.sentinel {
+		return false
+	}
+	s.tree.deleteNode(node)
+	return true
+}
+
+func (s *RBTreeSet[K]) Has(key K) bool {
+	return s.tree.ContainsKey(key)
+}
+
+// All iterates over the keys in ascending order.
+func (s *RBTreeSet[K]) All() iter.Seq[K] {
+	return s.keys(s.tree.InOrder())
+}
+
+// Backward iterates over the keys in descending order.
+func (s *RBTreeSet[K]) Backward() iter.Seq[K] {
+	return s.keys(s.tree.Backward())
+}
+
+func (s *RBTreeSet[K]) keys(seq iter.Seq2[K, struct{}]) iter.Seq[K] {
+	return func(yield func(key K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func (s *RBTreeSet[K]) Min() (K, bool) {
+	k, _, ok := s.tree.Min()
+	return k, ok
+}
+
+func (s *RBTreeSet[K]) Max() (K, bool) {
+	k, _, ok := s.tree.Max()
+	return k, ok
+}
+
+// Ceiling returns the least key greater than or equal to key.
+func (s *RBTreeSet[K]) Ceiling(key K) (K, bool) {
+	k, _, ok := s.tree.Ceiling(key)
+	return k, ok
+}
+
+// Higher returns the least key strictly greater than key.
+func (s *RBTreeSet[K]) Higher(key K) (K, bool) {
+	k, _, ok := s.tree.Higher(key)
+	return k, ok
+}
+
+// Floor returns the greatest key less than or equal to key.
+func (s *RBTreeSet[K]) Floor(key K) (K, bool) {
+	k, _, ok := s.tree.Floor(key)
+	return k, ok
+}
+
+// Lower returns the greatest key strictly less than key.
+func (s *RBTreeSet[K]) Lower(key K) (K, bool) {
+	k, _, ok := s.tree.Lower(key)
+	return k, ok
+}
+
+// Equal reports whether both sets hold the same keys, using s's
+// comparator.
+func (s *RBTreeSet[K]) Equal(other *RBTreeSet[K]) bool {
+	if s.Size() != other.Size() {
+		return false
+	}
+	next, stop := iter.Pull(other.All())
+	defer stop()
+	for k := range s.All() {
+		o, _ := next()
+		if s.tree.compare(k, o) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// The set operations below work like the map methods they call: they
+// build a new set in O(n + m) and leave both sets unchanged. The result
+// uses s's comparator.
+
+// Union returns the keys that are in s or in other.
+func (s *RBTreeSet[K]) Union(other *RBTreeSet[K]) *RBTreeSet[K] {
+	return &RBTreeSet[K]{tree: s.tree.Union(other.tree, keepFirst)}
+}
+
+// Intersection returns the keys that are in both s and other.
+func (s *RBTreeSet[K]) Intersection(other *RBTreeSet[K]) *RBTreeSet[K] {
+	return &RBTreeSet[K]{tree: s.tree.Intersection(other.tree, keepFirst)}
+}
+
+// Difference returns the keys of s that are not in other.
+func (s *RBTreeSet[K]) Difference(other *RBTreeSet[K]) *RBTreeSet[K] {
+	return &RBTreeSet[K]{tree: s.tree.Difference(other.tree)}
+}
+
+// SymmetricDifference returns the keys that are in exactly one of s and
+// other.
+func (s *RBTreeSet[K]) SymmetricDifference(other *RBTreeSet[K]) *RBTreeSet[K] {
+	return &RBTreeSet[K]{tree: s.tree.SymmetricDifference(other.tree)}
+}
+
+func keepFirst[K any](key K, a, b struct{}) struct{} {
+	return a
+}
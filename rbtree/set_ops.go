@@ -0,0 +1,236 @@
+package rbtree
+
+// Union returns a new map with every key present in r or other. When a key
+// is in both, its value is resolve(key, valueInR, valueInOther). Both maps
+// are left unchanged and the result uses r's comparator. It runs in
+// O(n + m); UnionInto is faster when one map is much smaller.
+func (r *RBTreeMap[K, V]) Union(other *RBTreeMap[K, V], resolve func(key K, a, b V) V) *RBTreeMap[K, V] {
+	return r.merge(other, true, true, resolve)
+}
+
+// Intersection returns a new map with the keys present in both r and
+// other, with values resolve(key, valueInR, valueInOther). Both maps are
+// left unchanged.
+func (r *RBTreeMap[K, V]) Intersection(other *RBTreeMap[K, V], resolve func(key K, a, b V) V) *RBTreeMap[K, V] {
+	return r.merge(other, false, false, resolve)
+}
+
+// Difference returns a new map with the entries of r whose keys are not in
+// other. Both maps are left unchanged.
+func (r *RBTreeMap[K, V]) Difference(other *RBTreeMap[K, V]) *RBTreeMap[K, V] {
+	return r.merge(other, true, false, nil)
+}
+
+// SymmetricDifference returns a new map with the entries whose keys are in
+// exactly one of r and other. Both maps are left unchanged.
+func (r *RBTreeMap[K, V]) SymmetricDifference(other *RBTreeMap[K, V]) *RBTreeMap[K, V] {
+	return r.merge(other, true, true, nil)
+}
+
+// merge walks r and other in key order and builds the result with
+// buildSorted. It keeps the keys found only in r if onlyR, those found
+// only in other if onlyOther, and, if resolve is not nil, those in both.
+// Sharing nodes with the arguments is not an option: every node has one
+// parent pointer, which only one live map can use.
+func (r *RBTreeMap[K, V]) merge(other *RBTreeMap[K, V], onlyR, onlyOther bool, resolve func(key K, a, b V) V) *RBTreeMap[K, V] {
+	result := r.adopt(r.sentinel)
+	a := r.appendNodes(nil, r.root)
+	b := other.appendNodes(nil, other.root)
+	nodes := make([]*Node[K, V], 0, len(a)+len(b))
+	add := func(key K, value V) {
+		nodes = append(nodes, &Node[K, V]{key: key, value: value, gen: result.gen})
+	}
+
+	for len(a) > 0 || len(b) > 0 {
+		c := -1
+		if len(a) == 0 {
+			c = 1
+		} else if len(b) > 0 {
+			c = r.compare(a[0].key, b[0].key)
+		}
+		switch {
+		case c < 0:
+			if onlyR {
+				add(a[0].key, a[0].value)
+			}
+			a = a[1:]
+		case c > 0:
+			if onlyOther {
+				add(b[0].key, b[0].value)
+			}
+			b = b[1:]
+		default:
+			if resolve != nil {
+				add(a[0].key, resolve(a[0].key, a[0].value, b[0].value))
+			}
+			a, b = a[1:], b[1:]
+		}
+	}
+	result.buildSorted(nodes)
+	return result
+}
+
+// appendNodes appends the nodes of the subtree rooted at node in key order.
+func (r *RBTreeMap[K, V]) appendNodes(nodes []*Node[K, V], node *Node[K, V]) []*Node[K, V] {
+	if node == r.sentinel {
+		return nodes
+	}
+	nodes = r.appendNodes(nodes, node.left)
+	nodes = append(nodes, node)
+	return r.appendNodes(nodes, node.right)
+}
+
+// The functions below store their result in dst and leave src empty. They
+// reuse the nodes of both maps and follow the join-based algorithms of
+// Blelloch, Ferizovic and Sun: expose the root of the smaller tree, split
+// the larger one around its key, recurse on both halves and join the
+// results. This takes O(m log(n/m + 1)) time for maps of sizes m <= n.
+
+// UnionInto stores in dst every key present in dst or src. When a key is
+// in both, its value is resolve(key, valueInDst, valueInSrc).
+func UnionInto[K any, V any](dst, src *RBTreeMap[K, V], resolve func(key K, a, b V) V) {
+	root, _ := dst.union(dst.root, dst.blackHeight(dst.root), src.root, src.blackHeight(src.root), resolve)
+	dst.consume(src, root)
+}
+
+// IntersectionInto keeps in dst the keys that are also in src, with values
+// resolve(key, valueInDst, valueInSrc).
+func IntersectionInto[K any, V any](dst, src *RBTreeMap[K, V], resolve func(key K, a, b V) V) {
+	root, _ := dst.intersection(dst.root, dst.blackHeight(dst.root), src.root, src.blackHeight(src.root), resolve)
+	dst.consume(src, root)
+}
+
+// DifferenceInto removes from dst the keys that are in src.
+func DifferenceInto[K any, V any](dst, src *RBTreeMap[K, V]) {
+	root, _ := dst.difference(dst.root, dst.blackHeight(dst.root), src.root, src.blackHeight(src.root))
+	dst.consume(src, root)
+}
+
+// SymmetricDifferenceInto stores in dst the entries whose keys are in
+// exactly one of dst and src.
+func SymmetricDifferenceInto[K any, V any](dst, src *RBTreeMap[K, V]) {
+	root, _ := dst.symmetricDifference(dst.root, dst.blackHeight(dst.root), src.root, src.blackHeight(src.root))
+	dst.consume(src, root)
+}
+
+// consume makes root, built from the nodes of r and other, the tree of r
+// and empties other.
+func (r *RBTreeMap[K, V]) consume(other *RBTreeMap[K, V], root *Node[K, V]) {
+	r.checkCopyable()
+	other.checkCopyable()
+	r.root = root
+	r.size = root.size
+	r.mod++
+	other.clear()
+}
+
+func (r *RBTreeMap[K, V]) union(a *Node[K, V], aHeight int, b *Node[K, V], bHeight int, resolve func(key K, a, b V) V) (*Node[K, V], int) {
+	if a == r.sentinel {
+		return b, bHeight
+	}
+	if b == r.sentinel {
+		return a, aHeight
+	}
+	if a.size <= b.size {
+		al, alHeight, mid, ar, arHeight := r.expose(a, aHeight)
+		bl, blHeight, found, br, brHeight := r.splitAt(b, bHeight, mid.key)
+		left, leftHeight := r.union(al, alHeight, bl, blHeight, resolve)
+		right, rightHeight := r.union(ar, arHeight, br, brHeight, resolve)
+		if found != r.sentinel {
+			mid.value = resolve(mid.key, mid.value, found.value)
+		}
+		return r.join(left, leftHeight, mid, right, rightHeight)
+	}
+	bl, blHeight, mid, br, brHeight := r.expose(b, bHeight)
+	al, alHeight, found, ar, arHeight := r.splitAt(a, aHeight, mid.key)
+	left, leftHeight := r.union(al, alHeight, bl, blHeight, resolve)
+	right, rightHeight := r.union(ar, arHeight, br, brHeight, resolve)
+	if found != r.sentinel {
+		mid.key = found.key
+		mid.value = resolve(found.key, found.value, mid.value)
+	}
+	return r.join(left, leftHeight, mid, right, rightHeight)
+}
+
+func (r *RBTreeMap[K, V]) intersection(a *Node[K, V], aHeight int, b *Node[K, V], bHeight int, resolve func(key K, a, b V) V) (*Node[K, V], int) {
+	if a == r.sentinel || b == r.sentinel {
+		return r.sentinel, 0
+	}
+	if a.size <= b.size {
+		al, alHeight, mid, ar, arHeight := r.expose(a, aHeight)
+		bl, blHeight, found, br, brHeight := r.splitAt(b, bHeight, mid.key)
+		left, leftHeight := r.intersection(al, alHeight, bl, blHeight, resolve)
+		right, rightHeight := r.intersection(ar, arHeight, br, brHeight, resolve)
+		if found == r.sentinel {
+			return r.concat(left, leftHeight, right, rightHeight)
+		}
+		mid.value = resolve(mid.key, mid.value, found.value)
+		return r.join(left, leftHeight, mid, right, rightHeight)
+	}
+	bl, blHeight, mid, br, brHeight := r.expose(b, bHeight)
+	al, alHeight, found, ar, arHeight := r.splitAt(a, aHeight, mid.key)
+	left, leftHeight := r.intersection(al, alHeight, bl, blHeight, resolve)
+	right, rightHeight := r.intersection(ar, arHeight, br, brHeight, resolve)
+	if found == r.sentinel {
+		return r.concat(left, leftHeight, right, rightHeight)
+	}
+	found.value = resolve(found.key, found.value, mid.value)
+	return r.join(left, leftHeight, found, right, rightHeight)
+}
+
+func (r *RBTreeMap[K, V]) difference(a *Node[K, V], aHeight int, b *Node[K, V], bHeight int) (*Node[K, V], int) {
+	if a == r.sentinel || b == r.sentinel {
+		return a, aHeight
+	}
+	if a.size <= b.size {
+		al, alHeight, mid, ar, arHeight := r.expose(a, aHeight)
+		bl, blHeight, found, br, brHeight := r.splitAt(b, bHeight, mid.key)
+		left, leftHeight := r.difference(al, alHeight, bl, blHeight)
+		right, rightHeight := r.difference(ar, arHeight, br, brHeight)
+		if found != r.sentinel {
+			return r.concat(left, leftHeight, right, rightHeight)
+		}
+		return r.join(left, leftHeight, mid, right, rightHeight)
+	}
+	bl, blHeight, mid, br, brHeight := r.expose(b, bHeight)
+	al, alHeight, _, ar, arHeight := r.splitAt(a, aHeight, mid.key)
+	left, leftHeight := r.difference(al, alHeight, bl, blHeight)
+	right, rightHeight := r.difference(ar, arHeight, br, brHeight)
+	return r.concat(left, leftHeight, right, rightHeight)
+}
+
+func (r *RBTreeMap[K, V]) symmetricDifference(a *Node[K, V], aHeight int, b *Node[K, V], bHeight int) (*Node[K, V], int) {
+	if a == r.sentinel {
+		return b, bHeight
+	}
+	if b == r.sentinel {
+		return a, aHeight
+	}
+	if a.size > b.size {
+		a, aHeight, b, bHeight = b, bHeight, a, aHeight
+	}
+	al, alHeight, mid, ar, arHeight := r.expose(a, aHeight)
+	bl, blHeight, found, br, brHeight := r.splitAt(b, bHeight, mid.key)
+	left, leftHeight := r.symmetricDifference(al, alHeight, bl, blHeight)
+	right, rightHeight := r.symmetricDifference(ar, arHeight, br, brHeight)
+	if found != r.sentinel {
+		return r.concat(left, leftHeight, right, rightHeight)
+	}
+	return r.join(left, leftHeight, mid, right, rightHeight)
+}
+
+// concat joins two detached subtrees without a middle node by taking the
+// largest node out of left and using it as the middle.
+func (r *RBTreeMap[K, V]) concat(left *Node[K, V], leftHeight int, right *Node[K, V], rightHeight int) (*Node[K, V], int) {
+	if left == r.sentinel {
+		return right, rightHeight
+	}
+	if right == r.sentinel {
+		return left, leftHeight
+	}
+	r.checkCopyable()
+	w := &RBTreeMap[K, V]{root: left, sentinel: r.sentinel, size: left.size, gen: r.gen, compare: r.compare, augment: r.augment}
+	mid := w.mutable(w.maximum(w.root))
+	w.deleteNode(mid)
+	return r.join(w.root, w.blackHeight(w.root), mid, right, rightHeight)
+}
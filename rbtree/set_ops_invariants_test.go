@@ -0,0 +1,60 @@
+package rbtree_test
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSetOperationsInvariants(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running set operations invariant test with seed: %d", seed)
+
+	build := func(n int) *rbtree.RBTreeMap[int, int] {
+		tree := rbtree.New[int, int]()
+		for i := 0; i < n; i++ {
+			tree.Insert(rng.Intn(4000), i)
+		}
+		return tree
+	}
+	add := func(key, a, b int) int { return a + b }
+
+	for round := 0; round < 100; round++ {
+		// Sizes from a few keys to a few thousand make the two trees
+		// differ a lot in height.
+		n, m := rng.Intn(3000), rng.Intn(3000)
+		if rng.Intn(2) == 0 {
+			m = rng.Intn(20)
+		}
+		a, b := build(n), build(m)
+
+		mustCheck(t, a.Union(b, add), "Union")
+		mustCheck(t, a.Intersection(b, add), "Intersection")
+		mustCheck(t, a.Difference(b), "Difference")
+		mustCheck(t, a.SymmetricDifference(b), "SymmetricDifference")
+		mustCheck(t, a, "a set operation on its receiver")
+		mustCheck(t, b, "a set operation on its argument")
+
+		into := []struct {
+			name string
+			op   func(dst, src *rbtree.RBTreeMap[int, int])
+		}{
+			{"UnionInto", func(dst, src *rbtree.RBTreeMap[int, int]) { rbtree.UnionInto(dst, src, add) }},
+			{"IntersectionInto", func(dst, src *rbtree.RBTreeMap[int, int]) { rbtree.IntersectionInto(dst, src, add) }},
+			{"DifferenceInto", rbtree.DifferenceInto[int, int]},
+			{"SymmetricDifferenceInto", rbtree.SymmetricDifferenceInto[int, int]},
+		}
+		c := into[rng.Intn(len(into))]
+		c.op(a, b)
+		mustCheck(t, a, c.name)
+		mustCheck(t, b, c.name+" on src")
+
+		for i := 0; i < 200; i++ {
+			a.Insert(rng.Intn(4000), i)
+			a.Remove(rng.Intn(4000))
+		}
+		mustCheck(t, a, "updates after "+c.name)
+	}
+}
@@ -0,0 +1,50 @@
+package rbtree
+
+import "iter"
+
+// Snapshot is a read-only view of an RBTreeMap at the moment Snapshot was
+// called. It is not affected by later changes to the map and may be read
+// from other goroutines while the map keeps being written.
+type Snapshot[K any, V any] struct {
+	tree *RBTreeMap[K, V]
+}
+
+// Snapshot returns a frozen view of the map in O(1). The nodes are shared
+// with the map, and later writes copy each shared node before changing it.
+func (r *RBTreeMap[K, V]) Snapshot() *Snapshot[K, V] {
+	r.checkCopyable()
+	frozen := &RBTreeMap[K, V]{
+		root:     r.root,
+		sentinel: r.sentinel,
+		size:     r.size,
+		gen:      r.gen,
+		frozen:   true,
+		compare:  r.compare,
+	}
+	r.gen = nextGen()
+	return &Snapshot[K, V]{tree: frozen}
+}
+
+func (s *Snapshot[K, V]) Size() int {
+	return s.tree.Size()
+}
+
+func (s *Snapshot[K, V]) Get(key K) (V, bool) {
+	return s.tree.Get(key)
+}
+
+func (s *Snapshot[K, V]) ContainsKey(key K) bool {
+	return s.tree.ContainsKey(key)
+}
+
+func (s *Snapshot[K, V]) InOrder() iter.Seq2[K, V] {
+	return s.tree.InOrder()
+}
+
+func (s *Snapshot[K, V]) LowerBound(key K) (K, V, bool) {
+	return s.tree.LowerBound(key)
+}
+
+func (s *Snapshot[K, V]) UpperBound(key K) (K, V, bool) {
+	return s.tree.UpperBound(key)
+}
@@ -0,0 +1,217 @@
+package rbtree
+
+// Split moves the entries with keys less than key into left and the rest
+// into right, leaving the receiver empty. Both maps share the receiver's
+// comparator. It runs in O(log n).
+func (r *RBTreeMap[K, V]) Split(key K) (left, right *RBTreeMap[K, V]) {
+	l, _, found, rr, rrHeight := r.splitAt(r.root, r.blackHeight(r.root), key)
+	if found != r.sentinel {
+		rr, _ = r.join(r.sentinel, 0, found, rr, rrHeight)
+	}
+	left = r.adopt(l)
+	right = r.adopt(rr)
+	r.clear()
+	return left, right
+}
+
+// Join moves every entry of left and right into a new map, leaving both
+// arguments empty. Every key in left must be less than every key in right,
+// otherwise Join panics. The result uses left's comparator. It runs in
+// O(log n).
+func Join[K any, V any](left, right *RBTreeMap[K, V]) *RBTreeMap[K, V] {
+	left.checkCopyable()
+	right.checkCopyable()
+	if left.root == left.sentinel {
+		result := right.adopt(right.root)
+		left.clear()
+		right.clear()
+		return result
+	}
+	if right.root == right.sentinel {
+		result := left.adopt(left.root)
+		left.clear()
+		right.clear()
+		return result
+	}
+	if left.compare(left.maximum(left.root).key, right.minimum(right.root).key) >= 0 {
+		panic("rbtree: Join requires every key in left to be less than every key in right")
+	}
+
+	m := right.minimum(right.root)
+	mid := &Node[K, V]{key: m.key, value: m.value, gen: left.gen}
+	right.deleteNode(m)
+
+	root, _ := left.join(left.root, left.blackHeight(left.root), mid, right.root, right.blackHeight(right.root))
+	result := left.adopt(root)
+	left.clear()
+	right.clear()
+	return result
+}
+
+// adopt wraps a detached subtree in a new map with the receiver's
+// comparator. The new map starts a fresh generation, so nodes it shares
+// with the receiver's snapshots are copied before they change.
+func (r *RBTreeMap[K, V]) adopt(root *Node[K, V]) *RBTreeMap[K, V] {
+	r.checkCopyable()
+	return &RBTreeMap[K, V]{
+		root:     root,
+		sentinel: r.sentinel,
+		size:     root.size,
+		gen:      nextGen(),
+		compare:  r.compare,
+
+		naturalOrder: r.naturalOrder,
+		augment:      r.augment,
+		keyCodec:     r.keyCodec,
+		valueCodec:   r.valueCodec,
+	}
+}
+
+// checkCopyable panics if r has a rotation hook. The owner of the hook,
+// IntervalTree, keeps pointers between nodes in their values and repairs
+// them only in its own Insert and Remove, so splitting, joining, combining,
+// decoding into or snapshotting such a map would silently break them.
+func (r *RBTreeMap[K, V]) checkCopyable() {
+	if r.rotated != nil {
+		panic("rbtree: a map with a rotation hook cannot be copied or rebuilt")
+	}
+}
+
+func (r *RBTreeMap[K, V]) clear() {
+	r.root = r.sentinel
+	r.size = 0
+	r.mod++
+	r.gen = nextGen()
+}
+
+// blackHeight counts the black nodes on the path from node down to a leaf,
+// including node itself.
+func (r *RBTreeMap[K, V]) blackHeight(node *Node[K, V]) int {
+	height := 0
+	for ; node != r.sentinel; node = node.left {
+		if node.color == BLACK {
+			height++
+		}
+	}
+	return height
+}
+
+// detach turns node into the black root of a standalone subtree and
+// returns it with its black height.
+func (r *RBTreeMap[K, V]) detach(node *Node[K, V], height int) (*Node[K, V], int) {
+	if node == r.sentinel {
+		return node, 0
+	}
+	if node.color == RED {
+		node = r.own(node)
+		node.color = BLACK
+		height++
+	}
+	node.parent = r.sentinel
+	return node, height
+}
+
+// splitAt splits a detached subtree into the keys less than key and the
+// keys greater than key. A node with an equal key is returned separately,
+// or the sentinel if there is none.
+func (r *RBTreeMap[K, V]) splitAt(node *Node[K, V], height int, key K) (*Node[K, V], int, *Node[K, V], *Node[K, V], int) {
+	if node == r.sentinel {
+		return r.sentinel, 0, r.sentinel, r.sentinel, 0
+	}
+	left, leftHeight, mid, right, rightHeight := r.expose(node, height)
+
+	c := r.compare(key, mid.key)
+	if c == 0 {
+		return left, leftHeight, mid, right, rightHeight
+	}
+	if c < 0 {
+		ll, llHeight, found, lr, lrHeight := r.splitAt(left, leftHeight, key)
+		joined, joinedHeight := r.join(lr, lrHeight, mid, right, rightHeight)
+		return ll, llHeight, found, joined, joinedHeight
+	}
+	rl, rlHeight, found, rr, rrHeight := r.splitAt(right, rightHeight, key)
+	joined, joinedHeight := r.join(left, leftHeight, mid, rl, rlHeight)
+	return joined, joinedHeight, found, rr, rrHeight
+}
+
+// expose takes apart the root of a detached subtree into its two detached
+// children and the root node itself.
+func (r *RBTreeMap[K, V]) expose(node *Node[K, V], height int) (*Node[K, V], int, *Node[K, V], *Node[K, V], int) {
+	r.checkCopyable()
+	childHeight := height
+	if node.color == BLACK {
+		childHeight--
+	}
+	mid := r.own(node)
+	left, leftHeight := r.detach(mid.left, childHeight)
+	right, rightHeight := r.detach(mid.right, childHeight)
+	return left, leftHeight, mid, right, rightHeight
+}
+
+// join links two detached subtrees with black roots through mid, whose
+// key lies between them, and returns the new root and its black height.
+// mid is attached red at the level where the black heights match and then
+// fixed up the same way as an inserted node.
+func (r *RBTreeMap[K, V]) join(left *Node[K, V], leftHeight int, mid *Node[K, V], right *Node[K, V], rightHeight int) (*Node[K, V], int) {
+	r.checkCopyable()
+	mid = r.own(mid)
+	mid.color = RED
+	w := &RBTreeMap[K, V]{sentinel: r.sentinel, gen: r.gen, compare: r.compare, augment: r.augment}
+
+	if leftHeight >= rightHeight {
+		w.root = left
+		parent, current, height := r.sentinel, left, leftHeight
+		for current.color != BLACK || height != rightHeight {
+			if current.color == BLACK {
+				height--
+			}
+			parent, current = current, current.right
+		}
+		mid.left, mid.right = current, right
+		mid.size = current.size + right.size + 1
+		if parent == r.sentinel {
+			w.root = mid
+		} else {
+			parent = w.mutable(parent)
+			parent.right = mid
+			for p := parent; p != r.sentinel; p = p.parent {
+				p.size += right.size + 1
+			}
+		}
+		mid.parent = parent
+	} else {
+		w.root = right
+		parent, current, height := r.sentinel, right, rightHeight
+		for current.color != BLACK || height != leftHeight {
+			if current.color == BLACK {
+				height--
+			}
+			parent, current = current, current.left
+		}
+		mid.left, mid.right = left, current
+		mid.size = left.size + current.size + 1
+		if parent == r.sentinel {
+			w.root = mid
+		} else {
+			parent = w.mutable(parent)
+			parent.left = mid
+			for p := parent; p != r.sentinel; p = p.parent {
+				p.size += left.size + 1
+			}
+		}
+		mid.parent = parent
+	}
+	if mid.left != r.sentinel {
+		mid.left.parent = mid
+	}
+	if mid.right != r.sentinel {
+		mid.right.parent = mid
+	}
+	w.augmentPath(mid)
+
+	height := max(leftHeight, rightHeight)
+	if w.fixInsert(mid) {
+		height++
+	}
+	return w.root, height
+}
@@ -0,0 +1,64 @@
+package rbtree_test
+
+import (
+	"github.com/A1exMedvedev/RB_tree_MAP/rbtree"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSplitJoinInvariants(t *testing.T) {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("Running split/join invariant test with seed: %d", seed)
+
+	for round := 0; round < 200; round++ {
+		tree := rbtree.New[int, int]()
+		var expected []int
+		for _, key := range rng.Perm(rng.Intn(3000)) {
+			tree.Insert(key, key)
+			expected = append(expected, key)
+		}
+		slices.Sort(expected)
+
+		key := rng.Intn(len(expected)+2) - 1
+		left, right := tree.Split(key)
+		mustCheck(t, left, "Split left")
+		mustCheck(t, right, "Split right")
+
+		// Splitting again makes the halves differ a lot in height.
+		inner, outer := left.Split(rng.Intn(key + 2))
+		mustCheck(t, inner, "second Split left")
+		mustCheck(t, outer, "second Split right")
+		left = rbtree.Join(inner, outer)
+		mustCheck(t, left, "Join of the left half")
+
+		tree = rbtree.Join(left, right)
+		mustCheck(t, tree, "Join")
+		if actual := collectKeys(tree); !slices.Equal(expected, actual) {
+			t.Fatalf("Round %d: content changed after Split and Join", round)
+		}
+
+		for i := 0; i < 100 && len(expected) > 0; i++ {
+			tree.Remove(expected[rng.Intn(len(expected))])
+			tree.Insert(rng.Intn(3000), 0)
+		}
+		mustCheck(t, tree, "updates after Join")
+	}
+}
+
+func mustCheck[K any, V any](t *testing.T, tree *rbtree.RBTreeMap[K, V], what string) {
+	t.Helper()
+	if err := rbtree.Check(tree); err != nil {
+		t.Fatalf("%s broke the tree: %v", what, err)
+	}
+}
+
+func collectKeys[K any, V any](tree *rbtree.RBTreeMap[K, V]) []K {
+	var keys []K
+	for k := range tree.InOrder() {
+		keys = append(keys, k)
+	}
+	return keys
+}
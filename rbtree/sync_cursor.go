@@ -0,0 +1,124 @@
+package rbtree
+
+// SyncCursor is a Cursor over a SyncMap. Every call takes the map's lock,
+// so other goroutines can use the map between calls. Their changes do not
+// invalidate the cursor: it remembers the entry it is on, and Next and Prev
+// move to the neighbours of that key in the map as it is now. Key and
+// Value return the entry as it was when the cursor reached it or last set
+// it. SetValue and Delete panic if another goroutine has removed the entry.
+type SyncCursor[K any, V any] struct {
+	m      *SyncMap[K, V]
+	cursor *Cursor[K, V]
+	key    K
+	value  V
+}
+
+func (m *SyncMap[K, V]) Cursor() *SyncCursor[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &SyncCursor[K, V]{m: m, cursor: m.load().Cursor()}
+}
+
+func (c *SyncCursor[K, V]) Valid() bool {
+	c.m.mu.RLock()
+	defer c.m.mu.RUnlock()
+	return c.cursor.Valid()
+}
+
+func (c *SyncCursor[K, V]) First() bool {
+	c.m.mu.RLock()
+	defer c.m.mu.RUnlock()
+	return c.moved(c.cursor.First())
+}
+
+func (c *SyncCursor[K, V]) Last() bool {
+	c.m.mu.RLock()
+	defer c.m.mu.RUnlock()
+	return c.moved(c.cursor.Last())
+}
+
+// Seek moves the cursor to the first entry whose key is not less than key.
+func (c *SyncCursor[K, V]) Seek(key K) bool {
+	c.m.mu.RLock()
+	defer c.m.mu.RUnlock()
+	return c.moved(c.cursor.Seek(key))
+}
+
+func (c *SyncCursor[K, V]) Next() bool {
+	c.m.mu.RLock()
+	defer c.m.mu.RUnlock()
+	if !c.cursor.Valid() {
+		return false
+	}
+	if c.stale() {
+		// Seek lands on the remembered key, or on the key after it when
+		// the entry is gone.
+		if !c.cursor.Seek(c.key) || c.m.tree.compare(c.cursor.Key(), c.key) != 0 {
+			return c.moved(c.cursor.Valid())
+		}
+	}
+	return c.moved(c.cursor.Next())
+}
+
+func (c *SyncCursor[K, V]) Prev() bool {
+	c.m.mu.RLock()
+	defer c.m.mu.RUnlock()
+	if !c.cursor.Valid() {
+		return false
+	}
+	if c.stale() && !c.cursor.Seek(c.key) {
+		// Every key is less than the remembered one.
+		return c.moved(c.cursor.Last())
+	}
+	return c.moved(c.cursor.Prev())
+}
+
+func (c *SyncCursor[K, V]) Key() K {
+	return c.key
+}
+
+func (c *SyncCursor[K, V]) Value() V {
+	return c.value
+}
+
+func (c *SyncCursor[K, V]) SetValue(value V) {
+	c.m.mu.Lock()
+	defer c.m.mu.Unlock()
+	c.find("SetValue")
+	c.cursor.SetValue(value)
+	c.value = value
+}
+
+// Delete removes the current entry and moves the cursor to the entry that
+// followed it.
+func (c *SyncCursor[K, V]) Delete() {
+	c.m.mu.Lock()
+	defer c.m.mu.Unlock()
+	c.find("Delete")
+	c.cursor.Delete()
+	c.moved(c.cursor.Valid())
+}
+
+// stale reports whether the map changed since the cursor last moved.
+func (c *SyncCursor[K, V]) stale() bool {
+	return c.cursor.mod != c.m.tree.mod
+}
+
+// find puts the cursor back on its entry after a change by another
+// goroutine, and panics if the entry is gone.
+func (c *SyncCursor[K, V]) find(op string) {
+	if !c.cursor.Valid() {
+		panic("rbtree: " + op + " on a cursor that is not positioned")
+	}
+	if c.stale() && (!c.cursor.Seek(c.key) || c.m.tree.compare(c.cursor.Key(), c.key) != 0) {
+		panic("rbtree: " + op + " on a cursor whose entry was removed")
+	}
+}
+
+// moved remembers the entry the cursor has just reached.
+func (c *SyncCursor[K, V]) moved(valid bool) bool {
+	if valid {
+		c.key, c.value = c.cursor.Key(), c.cursor.Value()
+	}
+	return valid
+}
@@ -0,0 +1,274 @@
+package rbtree
+
+import (
+	"cmp"
+	"io"
+	"iter"
+	"sync"
+)
+
+// SyncMap is an RBTreeMap guarded by a sync.RWMutex. Lookups and
+// iterators take the read lock and can run in parallel; everything that
+// changes the map takes the write lock.
+//
+// Iterators hold the read lock until the loop finishes, so the loop body
+// must not call any method of the same SyncMap, not even a lookup: once a
+// writer is waiting for the lock, a second RLock blocks behind it and the
+// loop never ends. To call back into the map while iterating, range over
+// a Snapshot instead.
+//
+// The zero SyncMap is an empty map ordered like one made by NewSyncMap.
+// If its keys have no natural order, its decoders return an error and its
+// other methods panic.
+type SyncMap[K any, V any] struct {
+	mu   sync.RWMutex
+	once sync.Once
+	tree *RBTreeMap[K, V]
+	// err is set when the zero SyncMap could not be given a comparator.
+	err error
+}
+
+func NewSyncMap[K cmp.Ordered, V any]() *SyncMap[K, V] {
+	return &SyncMap[K, V]{tree: New[K, V]()}
+}
+
+func NewSyncMapWithComparator[K any, V any](compare func(a, b K) int) *SyncMap[K, V] {
+	return &SyncMap[K, V]{tree: NewWithComparator[K, V](compare)}
+}
+
+func (m *SyncMap[K, V]) Size() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().Size()
+}
+
+func (m *SyncMap[K, V]) Insert(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.load().Insert(key, value)
+}
+
+func (m *SyncMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().Get(key)
+}
+
+func (m *SyncMap[K, V]) Remove(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.load().Remove(key)
+}
+
+func (m *SyncMap[K, V]) ContainsKey(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().ContainsKey(key)
+}
+
+func (m *SyncMap[K, V]) PopMin() (K, V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.load().PopMin()
+}
+
+func (m *SyncMap[K, V]) PopMax() (K, V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.load().PopMax()
+}
+
+// Split moves the entries with keys less than key into left and the rest
+// into right, leaving m empty.
+func (m *SyncMap[K, V]) Split(key K) (left, right *SyncMap[K, V]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, r := m.load().Split(key)
+	return &SyncMap[K, V]{tree: l}, &SyncMap[K, V]{tree: r}
+}
+
+// Snapshot returns a frozen view of the map. The snapshot can be read
+// without holding any lock while the SyncMap keeps being written.
+func (m *SyncMap[K, V]) Snapshot() *Snapshot[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.load().Snapshot()
+}
+
+func (m *SyncMap[K, V]) InOrder() iter.Seq2[K, V] {
+	return m.readLocked(func(r *RBTreeMap[K, V]) iter.Seq2[K, V] { return r.InOrder() })
+}
+
+func (m *SyncMap[K, V]) Backward() iter.Seq2[K, V] {
+	return m.readLocked(func(r *RBTreeMap[K, V]) iter.Seq2[K, V] { return r.Backward() })
+}
+
+func (m *SyncMap[K, V]) Range(lo, hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	return m.readLocked(func(r *RBTreeMap[K, V]) iter.Seq2[K, V] { return r.Range(lo, hi, opts...) })
+}
+
+func (m *SyncMap[K, V]) RangeFrom(lo K, opts ...RangeOption) iter.Seq2[K, V] {
+	return m.readLocked(func(r *RBTreeMap[K, V]) iter.Seq2[K, V] { return r.RangeFrom(lo, opts...) })
+}
+
+func (m *SyncMap[K, V]) RangeTo(hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	return m.readLocked(func(r *RBTreeMap[K, V]) iter.Seq2[K, V] { return r.RangeTo(hi, opts...) })
+}
+
+func (m *SyncMap[K, V]) RangeBackward(lo, hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	return m.readLocked(func(r *RBTreeMap[K, V]) iter.Seq2[K, V] { return r.RangeBackward(lo, hi, opts...) })
+}
+
+func (m *SyncMap[K, V]) RangeFromBackward(lo K, opts ...RangeOption) iter.Seq2[K, V] {
+	return m.readLocked(func(r *RBTreeMap[K, V]) iter.Seq2[K, V] { return r.RangeFromBackward(lo, opts...) })
+}
+
+func (m *SyncMap[K, V]) RangeToBackward(hi K, opts ...RangeOption) iter.Seq2[K, V] {
+	return m.readLocked(func(r *RBTreeMap[K, V]) iter.Seq2[K, V] { return r.RangeToBackward(hi, opts...) })
+}
+
+func (m *SyncMap[K, V]) LowerBound(key K) (K, V, bool) {
+	return m.readEntry((*RBTreeMap[K, V]).LowerBound, key)
+}
+
+func (m *SyncMap[K, V]) UpperBound(key K) (K, V, bool) {
+	return m.readEntry((*RBTreeMap[K, V]).UpperBound, key)
+}
+
+func (m *SyncMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return m.readEntry((*RBTreeMap[K, V]).Ceiling, key)
+}
+
+func (m *SyncMap[K, V]) Higher(key K) (K, V, bool) {
+	return m.readEntry((*RBTreeMap[K, V]).Higher, key)
+}
+
+func (m *SyncMap[K, V]) Floor(key K) (K, V, bool) {
+	return m.readEntry((*RBTreeMap[K, V]).Floor, key)
+}
+
+func (m *SyncMap[K, V]) Lower(key K) (K, V, bool) {
+	return m.readEntry((*RBTreeMap[K, V]).Lower, key)
+}
+
+func (m *SyncMap[K, V]) Min() (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().Min()
+}
+
+func (m *SyncMap[K, V]) Max() (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().Max()
+}
+
+func (m *SyncMap[K, V]) Rank(key K) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().Rank(key)
+}
+
+func (m *SyncMap[K, V]) CountRange(lo, hi K, opts ...RangeOption) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().CountRange(lo, hi, opts...)
+}
+
+func (m *SyncMap[K, V]) Select(i int) (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().Select(i)
+}
+
+func (m *SyncMap[K, V]) SetCodecs(key Codec[K], value Codec[V]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.load().SetCodecs(key, value)
+}
+
+func (m *SyncMap[K, V]) WriteTo(w io.Writer) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().WriteTo(w)
+}
+
+func (m *SyncMap[K, V]) ReadFrom(r io.Reader) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.once.Do(m.init)
+	return m.tree.ReadFrom(r)
+}
+
+func (m *SyncMap[K, V]) MarshalBinary() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().MarshalBinary()
+}
+
+func (m *SyncMap[K, V]) UnmarshalBinary(data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.once.Do(m.init)
+	return m.tree.UnmarshalBinary(data)
+}
+
+func (m *SyncMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().MarshalJSON()
+}
+
+func (m *SyncMap[K, V]) UnmarshalJSON(data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.once.Do(m.init)
+	return m.tree.UnmarshalJSON(data)
+}
+
+func (m *SyncMap[K, V]) GobEncode() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.load().GobEncode()
+}
+
+func (m *SyncMap[K, V]) GobDecode(data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.once.Do(m.init)
+	return m.tree.GobDecode(data)
+}
+
+// init gives a zero SyncMap its map, for example a struct field allocated
+// by encoding/gob. It runs once, before the first method reads m.tree.
+func (m *SyncMap[K, V]) init() {
+	if m.tree == nil {
+		m.tree = &RBTreeMap[K, V]{}
+		m.err = m.tree.initZero()
+	}
+}
+
+// load returns the map. The caller must hold the lock.
+func (m *SyncMap[K, V]) load() *RBTreeMap[K, V] {
+	m.once.Do(m.init)
+	if m.err != nil {
+		panic(m.err)
+	}
+	return m.tree
+}
+
+func (m *SyncMap[K, V]) readEntry(query func(r *RBTreeMap[K, V], key K) (K, V, bool), key K) (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return query(m.load(), key)
+}
+
+// readLocked holds the read lock for the whole iteration, including the
+// loop body.
+func (m *SyncMap[K, V]) readLocked(seq func(r *RBTreeMap[K, V]) iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(key K, value V) bool) {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+		seq(m.load())(yield)
+	}
+}